@@ -0,0 +1,74 @@
+package cloudfoundry
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+// annotateManagedResource - tags a newly created V3 resource with the
+// terraform.io/managed-by annotation. Failures are logged only, as older
+// Cloud Controller versions do not support V3 metadata.
+func annotateManagedResource(session *cfapi.Session, resourceType, guid string) {
+
+	if err := session.MetadataManager().SetManagedByAnnotation(resourceType, guid); err != nil {
+		session.Log.DebugMessage(
+			"unable to set '%s' annotation on %s/%s : %s",
+			cfapi.AnnotationManagedBy, resourceType, guid, err.Error())
+	}
+}
+
+// setPreventDestroyAnnotation - reflects the 'prevent_destroy_in_cf' flag on
+// the resource as a V3 annotation so tools outside of terraform can see the
+// protection. Failures are logged only, as older Cloud Controller versions do
+// not support V3 metadata.
+func setPreventDestroyAnnotation(session *cfapi.Session, resourceType, guid string, protected bool) {
+
+	mm := session.MetadataManager()
+
+	var err error
+	if protected {
+		err = mm.SetAnnotation(resourceType, guid, cfapi.AnnotationPreventDestroy, "true")
+	} else {
+		err = mm.RemoveAnnotation(resourceType, guid, cfapi.AnnotationPreventDestroy)
+	}
+	if err != nil {
+		session.Log.DebugMessage(
+			"unable to update '%s' annotation on %s/%s : %s",
+			cfapi.AnnotationPreventDestroy, resourceType, guid, err.Error())
+	}
+}
+
+// validateDestroyAllowed - refuses to delete a resource whose
+// 'prevent_destroy_in_cf' flag is set
+func validateDestroyAllowed(d *schema.ResourceData, resourceType string) error {
+
+	if d.Get("prevent_destroy_in_cf").(bool) {
+		return fmt.Errorf(
+			"refusing to delete %s/%s as 'prevent_destroy_in_cf' is set; unset the flag and apply before destroying",
+			resourceType, d.Id())
+	}
+	return nil
+}
+
+// validateManagedResource - when 'enforce_managed_by' is set on the provider,
+// refuses to modify or delete a resource that does not carry the
+// terraform.io/managed-by annotation
+func validateManagedResource(session *cfapi.Session, resourceType, guid string) error {
+
+	if !session.EnforceManagedBy {
+		return nil
+	}
+
+	managed, err := session.MetadataManager().IsManagedByTerraform(resourceType, guid)
+	if err != nil {
+		return err
+	}
+	if !managed {
+		return fmt.Errorf(
+			"refusing to modify %s/%s as it does not carry the '%s=%s' annotation and 'enforce_managed_by' is set",
+			resourceType, guid, cfapi.AnnotationManagedBy, cfapi.AnnotationManagedByValue)
+	}
+	return nil
+}
@@ -2,11 +2,14 @@ package cfapi
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/cli/cf/actors"
@@ -125,6 +128,31 @@ func (am *AppManager) FindApp(appName string) (app CCApp, err error) {
 	return app, nil
 }
 
+// FindAppInSpace -
+func (am *AppManager) FindAppInSpace(appName string, spaceID string) (app CCApp, err error) {
+
+	var count int
+
+	path := fmt.Sprintf("/v2/spaces/%s/apps?q=name:%s", spaceID, appName)
+	if err = am.ccGateway.ListPaginatedResources(am.apiEndpoint, path, CCAppResource{},
+		func(resource interface{}) bool {
+			appResource := resource.(CCAppResource)
+			app = appResource.Entity
+			app.ID = appResource.Metadata.GUID
+			count++
+			return true
+		}); err != nil {
+		return CCApp{}, err
+	}
+	switch {
+	case count == 0:
+		return CCApp{}, errors.NewModelNotFoundError("Application", appName)
+	case count > 1:
+		return CCApp{}, fmt.Errorf("more than one application named '%s' found in space %s", appName, spaceID)
+	}
+	return app, nil
+}
+
 // ReadApp -
 func (am *AppManager) ReadApp(appID string) (app CCApp, err error) {
 
@@ -196,7 +224,9 @@ func (am *AppManager) DeleteApp(appID string, deleteServiceBindings bool) (err e
 		}
 	}
 
-	return am.ccGateway.DeleteResource(am.apiEndpoint, fmt.Sprintf("/v2/apps/%s", appID))
+	return deleteWithRetry(am.log, "app "+appID, func() error {
+		return am.ccGateway.DeleteResource(am.apiEndpoint, fmt.Sprintf("/v2/apps/%s", appID))
+	})
 }
 
 // UploadApp -
@@ -255,7 +285,7 @@ func (am *AppManager) UploadApp(app CCApp, path string, addContent []map[string]
 		}()
 
 		if hasFileToUpload {
-			err = am.appZipper.Zip(uploadDir, zipFile)
+			err = writeDeterministicZip(uploadDir, zipFile)
 			if err != nil {
 				if emptyDirErr, ok := err.(*errors.EmptyDirError); ok {
 					return emptyDirErr
@@ -308,6 +338,26 @@ func (am *AppManager) StartApp(appID string, timeout time.Duration) (err error)
 	return nil
 }
 
+// RequestAppStart - flips the app to the started state without waiting for
+// its instances to come up, for workflows where health verification is
+// handled by an external system
+func (am *AppManager) RequestAppStart(appID string) (err error) {
+
+	var app, startApp CCApp
+	if app, err = am.ReadApp(appID); err != nil {
+		return err
+	}
+	if app.State != nil && *app.State == AppStopped {
+		startApp.ID = app.ID
+		startApp.Name = app.Name
+		startApp.State = &AppStarted
+		if _, err = am.UpdateApp(startApp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // StartDockerApp -
 func (am *AppManager) StartDockerApp(appID string, timeout time.Duration) (err error) {
 
@@ -435,11 +485,70 @@ func (am *AppManager) WaitForAppToStart(app CCApp, timeout time.Duration) (err e
 		}
 		am.log.UI.Say("App %s is running.", terminal.EntityNameColor(app.Name))
 	case <-time.After(timeout):
-		return fmt.Errorf("app %s failed to start after %d seconds", app.Name, timeout/time.Second)
+		err = fmt.Errorf("app %s failed to start after %d seconds", app.Name, timeout/time.Second)
+		if logs, lerr := am.RecentLogs(app.ID, 100); lerr == nil && len(logs) > 0 {
+			err = fmt.Errorf("%s; recent logs:\n%s", err.Error(), strings.Join(logs, "\n"))
+		} else if lerr != nil {
+			am.log.DebugMessage("Unable to retrieve recent logs for app %s from log-cache: %s", app.ID, lerr.Error())
+		}
+		return err
 	}
 	return nil
 }
 
+// logCacheEnvelope -
+type logCacheEnvelope struct {
+	Timestamp string            `json:"timestamp"`
+	Tags      map[string]string `json:"tags"`
+
+	Log *struct {
+		Payload string `json:"payload"`
+		Type    string `json:"type"`
+	} `json:"log"`
+}
+
+// logCacheReadResponse -
+type logCacheReadResponse struct {
+	Envelopes struct {
+		Batch []logCacheEnvelope `json:"batch"`
+	} `json:"envelopes"`
+}
+
+// RecentLogs - retrieves up to limit recent staging (STG) and application
+// (APP/PROC) log lines for the app from the log-cache API
+func (am *AppManager) RecentLogs(appID string, limit int) (lines []string, err error) {
+
+	logCacheEndpoint := strings.Replace(am.apiEndpoint, "://api.", "://log-cache.", 1)
+
+	path := fmt.Sprintf("%s/api/v1/read/%s?envelope_types=LOG&descending=true&limit=%d",
+		logCacheEndpoint, appID, limit)
+
+	response := logCacheReadResponse{}
+	if err = am.ccGateway.GetResource(path, &response); err != nil {
+		return nil, err
+	}
+
+	batch := response.Envelopes.Batch
+	for i := len(batch) - 1; i >= 0; i-- {
+		e := batch[i]
+		if e.Log == nil {
+			continue
+		}
+		sourceType := e.Tags["source_type"]
+		if sourceType != "STG" &&
+			!strings.HasPrefix(sourceType, "APP") &&
+			!strings.HasPrefix(sourceType, "PROC") {
+			continue
+		}
+		payload, derr := base64.StdEncoding.DecodeString(e.Log.Payload)
+		if derr != nil {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("[%s] %s", sourceType, strings.TrimRight(string(payload), "\n")))
+	}
+	return lines, nil
+}
+
 // ReadAppInstanceState -
 func (am *AppManager) ReadAppInstanceState(app CCApp) (map[string]interface{}, error) {
 	response := make(map[string]interface{})
@@ -449,6 +558,40 @@ func (am *AppManager) ReadAppInstanceState(app CCApp) (map[string]interface{}, e
 	return response, nil
 }
 
+// CountAppInstanceStates - returns how many instances of the app are
+// currently running and how many have crashed
+func (am *AppManager) CountAppInstanceStates(app CCApp) (running, crashed int, err error) {
+	response, err := am.ReadAppInstanceState(app)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, v := range response {
+		switch v.(map[string]interface{})["state"].(string) {
+		case "RUNNING":
+			running++
+		case "CRASHED", "FLAPPING":
+			crashed++
+		}
+	}
+	return running, crashed, nil
+}
+
+// ReadAppPackageUpdatedAt - when the package of the app was last updated,
+// i.e. when new bits were last staged
+func (am *AppManager) ReadAppPackageUpdatedAt(appID string) (string, error) {
+	response := make(map[string]interface{})
+	path := fmt.Sprintf("%s/v2/apps/%s", am.apiEndpoint, appID)
+	if err := am.ccGateway.GetResource(path, &response); err != nil {
+		return "", err
+	}
+	if entity, ok := response["entity"].(map[string]interface{}); ok {
+		if v, ok := entity["package_updated_at"].(string); ok {
+			return v, nil
+		}
+	}
+	return "", nil
+}
+
 // CountRunningAppInstances -
 func (am *AppManager) CountRunningAppInstances(app CCApp) (int, error) {
 	response, err := am.ReadAppInstanceState(app)
@@ -610,6 +753,51 @@ func (am *AppManager) CreateServiceBinding(
 	return bindingID, credentials, nil
 }
 
+// CreateUserProvidedServiceBinding - binds an app to a user-provided service
+// instance via the V3 API, which unlike V2 accepts arbitrary binding
+// parameters for user-provided instances
+func (am *AppManager) CreateUserProvidedServiceBinding(
+	appID string,
+	serviceInstanceID string,
+	params *map[string]interface{}) (bindingID string, credentials map[string]interface{}, err error) {
+
+	request := map[string]interface{}{
+		"type": "app",
+		"relationships": map[string]interface{}{
+			"app": map[string]interface{}{
+				"data": map[string]interface{}{"guid": appID},
+			},
+			"service_instance": map[string]interface{}{
+				"data": map[string]interface{}{"guid": serviceInstanceID},
+			},
+		},
+	}
+	if params != nil {
+		request["parameters"] = *params
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return bindingID, credentials, err
+	}
+
+	response := make(map[string]interface{})
+	err = am.ccGateway.CreateResource(am.apiEndpoint, "/v3/service_credential_bindings", bytes.NewReader(body), &response)
+	if err != nil {
+		return bindingID, credentials, err
+	}
+	bindingID = response["guid"].(string)
+
+	details := make(map[string]interface{})
+	path := fmt.Sprintf("%s/v3/service_credential_bindings/%s/details", am.apiEndpoint, bindingID)
+	if err = am.ccGateway.GetResource(path, &details); err != nil {
+		return bindingID, credentials, err
+	}
+	if v, ok := details["credentials"].(map[string]interface{}); ok {
+		credentials = v
+	}
+	return bindingID, credentials, nil
+}
+
 // ReadServiceBindingsByApp -
 func (am *AppManager) ReadServiceBindingsByApp(appID string) (mappings []map[string]interface{}, err error) {
 	return am.readServiceBindings(appID, "app_guid")
@@ -657,5 +845,335 @@ func (am *AppManager) readServiceBindings(id, key string) (mappings []map[string
 // DeleteServiceBinding -
 func (am *AppManager) DeleteServiceBinding(bindingID string) (err error) {
 	path := fmt.Sprintf("/v2/service_bindings/%s", bindingID)
-	return am.ccGateway.DeleteResource(am.apiEndpoint, path)
+	return deleteWithRetry(am.log, "service binding "+bindingID, func() error {
+		return am.ccGateway.DeleteResource(am.apiEndpoint, path)
+	})
+}
+
+// CCBuild -
+type CCBuild struct {
+	GUID    string `json:"guid"`
+	State   string `json:"state"`
+	Error   string `json:"error"`
+	Droplet *struct {
+		GUID string `json:"guid"`
+	} `json:"droplet"`
+}
+
+// CCPackageList -
+type CCPackageList struct {
+	Resources []struct {
+		GUID  string `json:"guid"`
+		State string `json:"state"`
+	} `json:"resources"`
+}
+
+// StageAppPackage - stages the app's most recent package via the V3 build
+// endpoint without starting the app and returns the resulting droplet GUID
+func (am *AppManager) StageAppPackage(appID string, timeout time.Duration) (dropletGUID string, err error) {
+
+	packages := CCPackageList{}
+	path := fmt.Sprintf("%s/v3/apps/%s/packages?order_by=-created_at", am.apiEndpoint, appID)
+	if err = am.ccGateway.GetResource(path, &packages); err != nil {
+		return "", err
+	}
+	if len(packages.Resources) == 0 {
+		return "", fmt.Errorf("app %s has no package to stage", appID)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"package": map[string]string{
+			"guid": packages.Resources[0].GUID,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	build := CCBuild{}
+	if err = am.ccGateway.CreateResource(am.apiEndpoint, "/v3/builds", bytes.NewReader(body), &build); err != nil {
+		return "", err
+	}
+
+	timeAfter := time.After(timeout)
+	for {
+		select {
+		case <-timeAfter:
+			return "", fmt.Errorf("staging of app %s package did not complete after %d seconds", appID, timeout/time.Second)
+		case <-time.After(appStatePingSleep):
+		}
+
+		path := fmt.Sprintf("%s/v3/builds/%s", am.apiEndpoint, build.GUID)
+		if err = am.ccGateway.GetResource(path, &build); err != nil {
+			return "", err
+		}
+		switch build.State {
+		case "FAILED":
+			return "", fmt.Errorf("staging of app %s package failed: %s", appID, build.Error)
+		case "STAGED":
+			if build.Droplet == nil {
+				return "", fmt.Errorf("staging of app %s produced no droplet", appID)
+			}
+			return build.Droplet.GUID, nil
+		}
+	}
+}
+
+// CCProcess -
+type CCProcess struct {
+	GUID       string `json:"guid"`
+	Type       string `json:"type"`
+	Instances  int    `json:"instances"`
+	MemoryInMB int    `json:"memory_in_mb"`
+	DiskInMB   int    `json:"disk_in_mb"`
+
+	// nil when the Cloud Controller predates log rate limits
+	LogRateLimit *int64 `json:"log_rate_limit_in_bytes_per_second,omitempty"`
+}
+
+// CCProcessList -
+type CCProcessList struct {
+	Resources []CCProcess `json:"resources"`
+}
+
+// ReadAppProcesses - lists the app's V3 processes with their scale settings
+func (am *AppManager) ReadAppProcesses(appID string) (processes []CCProcess, err error) {
+
+	list := CCProcessList{}
+	path := fmt.Sprintf("%s/v3/apps/%s/processes", am.apiEndpoint, appID)
+	if err = am.ccGateway.GetResource(path, &list); err != nil {
+		return nil, err
+	}
+	return list.Resources, nil
+}
+
+// UpdateProcessLogRateLimit - sets the process's log rate limit in bytes per
+// second via the V3 scale action; -1 means unlimited
+func (am *AppManager) UpdateProcessLogRateLimit(processGUID string, limit int64) (err error) {
+
+	body, err := json.Marshal(map[string]interface{}{
+		"log_rate_limit_in_bytes_per_second": limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("%s/v3/processes/%s/actions/scale", am.apiEndpoint, processGUID)
+	request, err := am.ccGateway.NewRequest("POST", path, am.config.AccessToken(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	_, err = am.ccGateway.PerformRequest(request)
+	return err
+}
+
+// CCJobResource -
+type CCJobResource struct {
+	Metadata resources.Metadata `json:"metadata"`
+	Entity   struct {
+		Status string `json:"status"`
+	} `json:"entity"`
+}
+
+// CopyBits - copies the source app's package bits to the destination app and
+// waits for the asynchronous copy job to finish
+func (am *AppManager) CopyBits(sourceAppID string, destAppID string, timeout time.Duration) (err error) {
+
+	body, err := json.Marshal(map[string]string{
+		"source_app_guid": sourceAppID,
+	})
+	if err != nil {
+		return err
+	}
+
+	job := CCJobResource{}
+	path := fmt.Sprintf("/v2/apps/%s/copy_bits", destAppID)
+	if err = am.ccGateway.CreateResource(am.apiEndpoint, path, bytes.NewReader(body), &job); err != nil {
+		return err
+	}
+
+	timeAfter := time.After(timeout)
+	for {
+		switch job.Entity.Status {
+		case "failed":
+			return fmt.Errorf("copying bits of app %s to app %s failed", sourceAppID, destAppID)
+		case "finished":
+			return nil
+		}
+
+		select {
+		case <-timeAfter:
+			return fmt.Errorf("copying bits of app %s to app %s did not complete after %d seconds",
+				sourceAppID, destAppID, timeout/time.Second)
+		case <-time.After(appStatePingSleep):
+		}
+
+		path := fmt.Sprintf("%s/v2/jobs/%s", am.apiEndpoint, job.Metadata.GUID)
+		if err = am.ccGateway.GetResource(path, &job); err != nil {
+			return err
+		}
+	}
+}
+
+// CCDroplet -
+type CCDroplet struct {
+	GUID  string `json:"guid"`
+	State string `json:"state"`
+	Error string `json:"error"`
+}
+
+// GetCurrentDroplet - returns the GUID of the app's current droplet
+func (am *AppManager) GetCurrentDroplet(appID string) (dropletGUID string, err error) {
+
+	droplet := CCDroplet{}
+	path := fmt.Sprintf("%s/v3/apps/%s/droplets/current", am.apiEndpoint, appID)
+	if err = am.ccGateway.GetResource(path, &droplet); err != nil {
+		return "", err
+	}
+	return droplet.GUID, nil
+}
+
+// GetCurrentPackage - returns the GUID of the app's most recent package
+func (am *AppManager) GetCurrentPackage(appID string) (packageGUID string, err error) {
+
+	response := struct {
+		Resources []struct {
+			GUID string `json:"guid"`
+		} `json:"resources"`
+	}{}
+	path := fmt.Sprintf("%s/v3/apps/%s/packages?order_by=-created_at&per_page=1", am.apiEndpoint, appID)
+	if err = am.ccGateway.GetResource(path, &response); err != nil {
+		return "", err
+	}
+	if len(response.Resources) == 0 {
+		return "", nil
+	}
+	return response.Resources[0].GUID, nil
+}
+
+// CopyDroplet - copies the given droplet to the destination app and waits
+// for the copy to complete, returning the GUID of the new droplet
+func (am *AppManager) CopyDroplet(sourceDropletGUID string, destAppID string, timeout time.Duration) (dropletGUID string, err error) {
+
+	body, err := json.Marshal(map[string]interface{}{
+		"relationships": map[string]interface{}{
+			"app": map[string]interface{}{
+				"data": map[string]string{
+					"guid": destAppID,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	droplet := CCDroplet{}
+	path := fmt.Sprintf("/v3/droplets?source_guid=%s", sourceDropletGUID)
+	if err = am.ccGateway.CreateResource(am.apiEndpoint, path, bytes.NewReader(body), &droplet); err != nil {
+		return "", err
+	}
+
+	timeAfter := time.After(timeout)
+	for {
+		switch droplet.State {
+		case "FAILED", "EXPIRED":
+			return "", fmt.Errorf("copy of droplet %s to app %s failed: %s", sourceDropletGUID, destAppID, droplet.Error)
+		case "STAGED":
+			return droplet.GUID, nil
+		}
+
+		select {
+		case <-timeAfter:
+			return "", fmt.Errorf("copy of droplet %s to app %s did not complete after %d seconds",
+				sourceDropletGUID, destAppID, timeout/time.Second)
+		case <-time.After(appStatePingSleep):
+		}
+
+		path := fmt.Sprintf("%s/v3/droplets/%s", am.apiEndpoint, droplet.GUID)
+		if err = am.ccGateway.GetResource(path, &droplet); err != nil {
+			return "", err
+		}
+	}
+}
+
+// SetCurrentDroplet - assigns the given droplet as the app's current droplet
+func (am *AppManager) SetCurrentDroplet(appID, dropletGUID string) (err error) {
+
+	body, err := json.Marshal(map[string]interface{}{
+		"data": map[string]string{
+			"guid": dropletGUID,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("%s/v3/apps/%s/relationships/current_droplet", am.apiEndpoint, appID)
+	request, err := am.ccGateway.NewRequest("PATCH", path, am.config.AccessToken(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	_, err = am.ccGateway.PerformRequest(request)
+	return err
+}
+
+// UpdateAppStackLifecycle - points the app's V3 buildpack lifecycle at the
+// stack identified by stackGUID so the next restage builds on the new stack
+func (am *AppManager) UpdateAppStackLifecycle(appID string, stackGUID string) (err error) {
+
+	stack := struct {
+		Entity struct {
+			Name string `json:"name"`
+		} `json:"entity"`
+	}{}
+	if err = am.ccGateway.GetResource(fmt.Sprintf("%s/v2/stacks/%s", am.apiEndpoint, stackGUID), &stack); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"lifecycle": map[string]interface{}{
+			"type": "buildpack",
+			"data": map[string]interface{}{
+				"stack": stack.Entity.Name,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("%s/v3/apps/%s", am.apiEndpoint, appID)
+	request, err := am.ccGateway.NewRequest("PATCH", path, am.config.AccessToken(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	_, err = am.ccGateway.PerformRequest(request)
+	return err
+}
+
+// DownloadDroplet - downloads the app's current droplet to the given file
+func (am *AppManager) DownloadDroplet(appID, path string) (err error) {
+
+	request, err := am.ccGateway.NewRequest("GET",
+		fmt.Sprintf("%s/v2/apps/%s/droplet/download", am.apiEndpoint, appID),
+		am.config.AccessToken(), nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := am.ccGateway.PerformRequest(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, response.Body)
+	return err
 }
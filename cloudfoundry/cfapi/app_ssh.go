@@ -0,0 +1,140 @@
+package cfapi
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// fingerprint lengths of the formats the platform may advertise in
+// 'app_ssh_host_key_fingerprint', matching those accepted by the cf CLI
+const (
+	md5FingerprintLength          = 47 // inclusive of space between bytes
+	hexSha1FingerprintLength      = 59 // inclusive of space between bytes
+	base64Sha256FingerprintLength = 43
+)
+
+// AppSSHTunnel - a local TCP listener forwarding connections to a port of an
+// app instance through the platform's SSH proxy
+type AppSSHTunnel struct {
+	LocalAddress string
+
+	listener net.Listener
+	client   *ssh.Client
+}
+
+// OpenAppSSHTunnel - opens an SSH connection through the platform's SSH
+// proxy to the given app instance, authenticating with a one time code
+// issued by UAA, and starts a local listener forwarding connections to the
+// given port inside the instance; the tunnel stays open until Close is
+// called or the provider process exits
+func (s *Session) OpenAppSSHTunnel(appID string, instance, remotePort, localPort int) (tunnel *AppSSHTunnel, err error) {
+
+	code, err := s.authManager.Authorize(s.config.AccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	clientConfig := &ssh.ClientConfig{
+		User: fmt.Sprintf("cf:%s/%d", appID, instance),
+		Auth: []ssh.AuthMethod{
+			ssh.Password(code),
+		},
+		HostKeyCallback: fingerprintHostKeyCallback(s.ccInfo.AppSSHHostKeyFingerprint),
+	}
+	client, err := ssh.Dial("tcp", s.ccInfo.AppSSHEndpoint, clientConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", localPort))
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	tunnel = &AppSSHTunnel{
+		LocalAddress: listener.Addr().String(),
+		listener:     listener,
+		client:       client,
+	}
+	go tunnel.serve(remotePort, s.Log)
+
+	s.Log.DebugMessage("Opened SSH tunnel to app %s/%d port %d at %s",
+		appID, instance, remotePort, tunnel.LocalAddress)
+	return tunnel, nil
+}
+
+// fingerprintHostKeyCallback - verifies the SSH proxy's host key against the
+// fingerprint the platform advertises in 'app_ssh_host_key_fingerprint',
+// the same way the cf CLI does; the fingerprint format is detected by length
+func fingerprintHostKeyCallback(expectedFingerprint string) ssh.HostKeyCallback {
+
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		var fingerprint string
+
+		switch len(expectedFingerprint) {
+		case base64Sha256FingerprintLength:
+			sum := sha256.Sum256(key.Marshal())
+			fingerprint = base64.RawStdEncoding.EncodeToString(sum[:])
+		case hexSha1FingerprintLength:
+			sum := sha1.Sum(key.Marshal())
+			fingerprint = strings.Replace(fmt.Sprintf("% x", sum), " ", ":", -1)
+		case md5FingerprintLength:
+			sum := md5.Sum(key.Marshal())
+			fingerprint = strings.Replace(fmt.Sprintf("% x", sum), " ", ":", -1)
+		case 0:
+			sum := md5.Sum(key.Marshal())
+			fingerprint = strings.Replace(fmt.Sprintf("% x", sum), " ", ":", -1)
+			return fmt.Errorf(
+				"unable to verify the identity of the SSH proxy; the platform advertises no host key fingerprint "+
+					"and the fingerprint of the received key was '%s'", fingerprint)
+		default:
+			return fmt.Errorf("unsupported host key fingerprint format '%s'", expectedFingerprint)
+		}
+
+		if fingerprint != expectedFingerprint {
+			return fmt.Errorf(
+				"host key verification of the SSH proxy failed; the fingerprint of the received key was '%s'", fingerprint)
+		}
+		return nil
+	}
+}
+
+// serve - accepts local connections and pipes each one to the remote port
+func (t *AppSSHTunnel) serve(remotePort int, log *Logger) {
+
+	for {
+		local, err := t.listener.Accept()
+		if err != nil {
+			return
+		}
+		remote, err := t.client.Dial("tcp", fmt.Sprintf("localhost:%d", remotePort))
+		if err != nil {
+			log.DebugMessage("SSH tunnel connection to remote port %d failed: %s", remotePort, err.Error())
+			local.Close()
+			continue
+		}
+		go func() {
+			io.Copy(remote, local)
+			remote.Close()
+		}()
+		go func() {
+			io.Copy(local, remote)
+			local.Close()
+		}()
+	}
+}
+
+// Close - tears down the tunnel and its local listener
+func (t *AppSSHTunnel) Close() {
+	t.listener.Close()
+	t.client.Close()
+}
@@ -0,0 +1,91 @@
+package cfapi
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/errors"
+)
+
+// zipEpoch - fixed timestamp written into every zip entry so identical
+// sources produce byte-identical archives regardless of checkout time
+var zipEpoch = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// writeDeterministicZip - zips the given directory with entries in lexical
+// order and normalized timestamps, so identical sources produce identical
+// checksums across machines and the Cloud Controller's resource matching can
+// reliably detect no-op uploads
+func writeDeterministicZip(dir string, targetFile *os.File) (err error) {
+
+	writer := zip.NewWriter(targetFile)
+	entries := 0
+
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(relPath)
+
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		header.Modified = zipEpoch
+		if info.IsDir() {
+			header.Name = name + "/"
+		} else {
+			header.Method = zip.Deflate
+		}
+
+		out, err := writer.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		entries++
+
+		if info.IsDir() || !info.Mode().IsRegular() {
+			if info.Mode()&os.ModeSymlink != 0 {
+				target, err := os.Readlink(path)
+				if err != nil {
+					return err
+				}
+				_, err = out.Write([]byte(filepath.ToSlash(target)))
+				return err
+			}
+			return nil
+		}
+
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		_, err = io.Copy(out, in)
+		return err
+	})
+	if err != nil {
+		writer.Close()
+		return err
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+	if entries == 0 {
+		return errors.NewEmptyDirError(dir)
+	}
+
+	_, err = targetFile.Seek(0, io.SeekStart)
+	return err
+}
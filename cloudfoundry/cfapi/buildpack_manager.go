@@ -33,6 +33,7 @@ type CCBuildpack struct {
 	ID string
 
 	Name     string `json:"name"`
+	Stack    string `json:"stack,omitempty"`
 	Position *int   `json:"position,omitempty"`
 	Enabled  *bool  `json:"enabled,omitempty"`
 	Locked   *bool  `json:"locked,omitempty"`
@@ -93,6 +94,19 @@ func (bpm *BuildpackManager) ReadBuildpack(buildpackID string) (bp CCBuildpack,
 	return bp, nil
 }
 
+// ListBuildpacks - returns all buildpacks installed on the platform
+func (bpm *BuildpackManager) ListBuildpacks() (buildpacks []CCBuildpack, err error) {
+	err = bpm.ccGateway.ListPaginatedResources(bpm.apiEndpoint, "/v2/buildpacks", CCBuildpackResource{},
+		func(resource interface{}) bool {
+			bpResource := resource.(CCBuildpackResource)
+			bp := bpResource.Entity
+			bp.ID = bpResource.Metadata.GUID
+			buildpacks = append(buildpacks, bp)
+			return true
+		})
+	return buildpacks, err
+}
+
 // CreateBuildpack -
 func (bpm *BuildpackManager) CreateBuildpack(
 	name string,
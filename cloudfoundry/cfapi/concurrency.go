@@ -0,0 +1,49 @@
+package cfapi
+
+import "sync"
+
+// ServiceBrokerLimiter - caps the number of service operations (instance
+// creates, updates and deletes, bindings and keys) performed concurrently
+// against each broker, so parallel applies do not overwhelm brokers that
+// only handle a few provisioning requests at a time
+type ServiceBrokerLimiter struct {
+	mutex sync.Mutex
+	slots map[string]chan struct{}
+}
+
+// NewServiceBrokerLimiter - creates a limiter from a map of broker name to
+// ceiling; returns nil when no ceilings are configured so callers can skip
+// broker resolution entirely
+func NewServiceBrokerLimiter(limits map[string]int) *ServiceBrokerLimiter {
+
+	slots := make(map[string]chan struct{})
+	for broker, limit := range limits {
+		if limit > 0 {
+			slots[broker] = make(chan struct{}, limit)
+		}
+	}
+	if len(slots) == 0 {
+		return nil
+	}
+	return &ServiceBrokerLimiter{slots: slots}
+}
+
+// Acquire - blocks until a slot for the named broker is free and returns the
+// function releasing it; brokers without a configured ceiling are never
+// limited and the limiter is safe to call with a nil receiver
+func (l *ServiceBrokerLimiter) Acquire(broker string) func() {
+
+	if l == nil {
+		return func() {}
+	}
+	l.mutex.Lock()
+	slot, ok := l.slots[broker]
+	l.mutex.Unlock()
+	if !ok {
+		return func() {}
+	}
+	slot <- struct{}{}
+	return func() {
+		<-slot
+	}
+}
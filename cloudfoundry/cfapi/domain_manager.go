@@ -35,6 +35,7 @@ type CCDomain struct {
 	// Shared domain fields
 	RouterGroupGUID string `json:"router_group_guid,omitempty"`
 	RouterType      string `json:"router_group_type,omitempty"`
+	Internal        bool   `json:"internal,omitempty"`
 
 	// Private domain fields
 	OwningOrganizationGUID string `json:"owning_organization_guid,omitempty"`
@@ -258,3 +259,27 @@ func (dm *DomainManager) FindRouterGroupByName(name string) (routerGroup models.
 	}
 	return routerGroup, nil
 }
+
+// FindRouterGroup - resolves a router group by name or GUID, so
+// configurations written when 'router_group' took the GUID directly keep
+// working alongside the name based lookup
+func (dm *DomainManager) FindRouterGroup(nameOrGUID string) (routerGroup models.RouterGroup, err error) {
+
+	err = dm.routingAPIRepo.ListRouterGroups(
+		func(rg models.RouterGroup) bool {
+			if rg.Name == nameOrGUID || rg.GUID == nameOrGUID {
+				routerGroup = rg
+				return false
+			}
+			return true
+		})
+
+	if err != nil {
+		return models.RouterGroup{}, err
+	}
+
+	if routerGroup.Name != nameOrGUID && routerGroup.GUID != nameOrGUID {
+		return models.RouterGroup{}, fmt.Errorf("Router group with name or id '%s' was not found", nameOrGUID)
+	}
+	return routerGroup, nil
+}
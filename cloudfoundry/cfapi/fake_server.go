@@ -0,0 +1,118 @@
+package cfapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+// FakeCloudController - an in-process HTTP server emulating just enough of
+// the Cloud Controller and UAA APIs for a Session to be established against
+// it. Arbitrary endpoints can be stubbed per test with canned JSON
+// responses, and the requests received are recorded for assertions, so
+// resource logic can be unit tested without a real foundation.
+type FakeCloudController struct {
+	server *httptest.Server
+
+	mutex    sync.Mutex
+	stubs    map[string]fakeResponse
+	requests []string
+}
+
+type fakeResponse struct {
+	status int
+	body   interface{}
+}
+
+// NewFakeCloudController - starts the fake server; callers must Close it
+// when done
+func NewFakeCloudController() *FakeCloudController {
+
+	f := &FakeCloudController{
+		stubs: make(map[string]fakeResponse),
+	}
+	f.server = httptest.NewServer(http.HandlerFunc(f.handle))
+	return f
+}
+
+// URL - the endpoint to pass to NewSession as the API URL
+func (f *FakeCloudController) URL() string {
+	return f.server.URL
+}
+
+// Close - shuts the fake server down
+func (f *FakeCloudController) Close() {
+	f.server.Close()
+}
+
+// Stub - registers a canned JSON response for the given method and path,
+// replacing any previous stub for it
+func (f *FakeCloudController) Stub(method, path string, status int, body interface{}) {
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.stubs[method+" "+path] = fakeResponse{status: status, body: body}
+}
+
+// Requests - the "METHOD /path" lines of all requests received so far
+func (f *FakeCloudController) Requests() []string {
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]string{}, f.requests...)
+}
+
+// handle - serves stubbed responses, with built-in handlers for the
+// discovery and token endpoints a Session needs during its handshake
+func (f *FakeCloudController) handle(w http.ResponseWriter, r *http.Request) {
+
+	f.mutex.Lock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	stub, stubbed := f.stubs[r.Method+" "+r.URL.Path]
+	f.mutex.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if stubbed {
+		w.WriteHeader(stub.status)
+		json.NewEncoder(w).Encode(stub.body)
+		return
+	}
+
+	switch r.URL.Path {
+
+	case "/v2/info", "/login":
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"api_version":            "2.100.0",
+			"authorization_endpoint": f.server.URL,
+			"token_endpoint":         f.server.URL,
+			"app_ssh_endpoint":       "",
+			"app_ssh_oauth_client":   "ssh-proxy",
+			"routing_endpoint":       f.server.URL + "/routing",
+		})
+
+	case "/oauth/token":
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  "fake-access-token",
+			"token_type":    "bearer",
+			"refresh_token": "fake-refresh-token",
+			"expires_in":    3600,
+		})
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"code":        10000,
+			"error_code":  "CF-NotFound",
+			"description": fmt.Sprintf("Unknown request: %s %s", r.Method, r.URL.Path),
+		})
+	}
+}
+
+// NewFakeSession - establishes a Session against the fake server, using the
+// built-in discovery and token endpoints
+func NewFakeSession(f *FakeCloudController) (*Session, error) {
+	return NewSession(f.URL(), "fake-user", "fake-password", "", "", "", true)
+}
@@ -0,0 +1,94 @@
+package cfapi
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/net"
+)
+
+// Instrumentation receives one observation per HTTP request issued against
+// the Cloud Controller or UAA APIs. Implementations can feed the
+// observations into counters and histograms (e.g. Prometheus) to track API
+// latency and error rates per endpoint. Implementations must be safe for
+// concurrent use.
+type Instrumentation interface {
+
+	// ObserveRequest is called after every request with the HTTP method,
+	// the request path with GUID segments normalized to ":guid" (to keep
+	// the label cardinality bounded), the response status code (0 if no
+	// response was received), the request duration and any transport
+	// level error.
+	ObserveRequest(method string, endpoint string, status int, duration time.Duration, err error)
+}
+
+var (
+	instrumentationMutex sync.RWMutex
+	instrumentation      Instrumentation
+)
+
+// SetInstrumentation - installs the given instrumentation for all sessions
+// in the process. Passing nil removes a previously installed instrumentation.
+// By default no instrumentation is active and requests are not observed.
+func SetInstrumentation(i Instrumentation) {
+	instrumentationMutex.Lock()
+	instrumentation = i
+	instrumentationMutex.Unlock()
+}
+
+func currentInstrumentation() Instrumentation {
+	instrumentationMutex.RLock()
+	defer instrumentationMutex.RUnlock()
+	return instrumentation
+}
+
+// the cli gateways create their http client through the replaceable
+// net.NewHTTPClient constructor, so wrapping it here observes every gateway
+// request without patching the vendored cli
+func init() {
+	newHTTPClient := net.NewHTTPClient
+	net.NewHTTPClient = func(tr *http.Transport, dumper net.RequestDumper) net.HTTPClientInterface {
+		return &instrumentedHTTPClient{newHTTPClient(tr, dumper)}
+	}
+}
+
+type instrumentedHTTPClient struct {
+	net.HTTPClientInterface
+}
+
+func (c *instrumentedHTTPClient) Do(request *http.Request) (*http.Response, error) {
+
+	i := currentInstrumentation()
+	if i == nil {
+		return c.HTTPClientInterface.Do(request)
+	}
+
+	start := time.Now()
+	response, err := c.HTTPClientInterface.Do(request)
+
+	status := 0
+	if response != nil {
+		status = response.StatusCode
+	}
+	i.ObserveRequest(request.Method, normalizeEndpoint(request.URL.Path), status, time.Since(start), err)
+
+	return response, err
+}
+
+var guidSegment = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// normalizeEndpoint - replaces GUID path segments with ":guid" so all
+// requests against the same CC endpoint share one metric label
+func normalizeEndpoint(path string) string {
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if guidSegment.MatchString(segment) {
+			segments[i] = ":guid"
+		}
+	}
+	return strings.Join(segments, "/")
+}
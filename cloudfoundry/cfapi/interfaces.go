@@ -0,0 +1,65 @@
+package cfapi
+
+import "time"
+
+// The interfaces below extract the manager operations the resource logic
+// depends on, so it can be exercised against fakes backed by the
+// FakeCloudController instead of a real foundation. The concrete managers
+// are asserted to satisfy them at compile time; methods added to a manager
+// for use by resources should be added to the matching interface as well.
+
+// Apps - the AppManager operations used by resource logic
+type Apps interface {
+	FindApp(appName string) (CCApp, error)
+	FindAppInSpace(appName string, spaceID string) (CCApp, error)
+	ReadApp(appID string) (CCApp, error)
+	CreateApp(a CCApp) (CCApp, error)
+	UpdateApp(a CCApp) (CCApp, error)
+	DeleteApp(appID string, deleteServiceBindings bool) error
+	StartApp(appID string, timeout time.Duration) error
+	StopApp(appID string, timeout time.Duration) error
+	RequestAppStart(appID string) error
+	RestageApp(appID string, timeout time.Duration) error
+	WaitForAppToStart(app CCApp, timeout time.Duration) error
+	ReadAppInstanceState(app CCApp) (map[string]interface{}, error)
+	CountRunningAppInstances(app CCApp) (int, error)
+	CreateServiceBinding(appID, serviceInstanceID string, params *map[string]interface{}) (string, map[string]interface{}, error)
+	DeleteServiceBinding(bindingID string) error
+	ReadServiceBindingsByApp(appID string) ([]map[string]interface{}, error)
+}
+
+var _ Apps = (*AppManager)(nil)
+
+// Routes - the RouteManager operations used by resource logic
+type Routes interface {
+	FindRoute(domain string, hostname, path *string, port *int) (CCRoute, error)
+	FindRouteInDomain(domainGUID string, hostname string) (CCRoute, error)
+	ReadRoute(routeID string) (CCRoute, error)
+	CreateRoute(r CCRoute, randomPort bool) (CCRoute, error)
+	UpdateRoute(r CCRoute) (CCRoute, error)
+	DeleteRoute(routeID string) error
+	CreateRouteMapping(routeID, appID string, port *int) (string, error)
+	ReadRouteMapping(mappingID string) (CCRouteMapping, error)
+	ReadRouteMappingsByRoute(routeID string) ([]map[string]interface{}, error)
+	ReadRouteMappingsByApp(appID string) ([]map[string]interface{}, error)
+	DeleteRouteMapping(mappingID string) error
+}
+
+var _ Routes = (*RouteManager)(nil)
+
+// Services - the ServiceManager operations used by resource logic
+type Services interface {
+	ReadServicePlan(planID string) (CCServicePlan, error)
+	CreateServiceInstance(name, servicePlanID, spaceID string, params map[string]interface{}, tags []string) (string, error)
+	UpdateServiceInstance(serviceInstanceID, name, servicePlanID string, params map[string]interface{}, tags []string) (CCServiceInstance, error)
+	RenameServiceInstance(serviceInstanceID, name string) error
+	ReadServiceInstance(serviceInstanceID string) (CCServiceInstance, error)
+	FindServiceInstance(name string, spaceID string) (CCServiceInstance, error)
+	DeleteServiceInstance(serviceInstanceID string, recursive bool) error
+	CreateServiceKey(name, serviceID string, params map[string]interface{}) (CCServiceKey, error)
+	ReadServiceKey(serviceKeyID string) (CCServiceKey, error)
+	DeleteServiceKey(serviceKeyID string) error
+	ServiceBrokerNameForPlan(servicePlanID string) (string, error)
+}
+
+var _ Services = (*ServiceManager)(nil)
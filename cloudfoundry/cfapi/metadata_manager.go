@@ -0,0 +1,196 @@
+package cfapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/net"
+)
+
+// AnnotationManagedBy - V3 annotation key identifying the tool that owns a resource
+const AnnotationManagedBy = "terraform.io/managed-by"
+
+// AnnotationManagedByValue - annotation value set on resources created by this provider
+const AnnotationManagedByValue = "terraform"
+
+// AnnotationPreventDestroy - V3 annotation key marking a resource as protected from deletion
+const AnnotationPreventDestroy = "terraform.io/prevent-destroy"
+
+// MetadataManager -
+type MetadataManager struct {
+	log *Logger
+
+	config    coreconfig.Reader
+	ccGateway net.Gateway
+
+	apiEndpoint string
+}
+
+// CCMetadata -
+type CCMetadata struct {
+	Annotations map[string]*string `json:"annotations,omitempty"`
+	Labels      map[string]*string `json:"labels,omitempty"`
+}
+
+// CCMetadataResource -
+type CCMetadataResource struct {
+	GUID     string     `json:"guid"`
+	Metadata CCMetadata `json:"metadata"`
+}
+
+// newMetadataManager -
+func newMetadataManager(config coreconfig.Reader, ccGateway net.Gateway, logger *Logger) (mm *MetadataManager, err error) {
+
+	mm = &MetadataManager{
+		log:         logger,
+		config:      config,
+		ccGateway:   ccGateway,
+		apiEndpoint: config.APIEndpoint(),
+	}
+
+	if len(mm.apiEndpoint) == 0 {
+		return nil, errors.New("API endpoint missing from config file")
+	}
+
+	return mm, nil
+}
+
+// SetAnnotation - sets a single V3 annotation on the given resource
+// (resourceType is the V3 collection name e.g. "apps", "routes")
+func (mm *MetadataManager) SetAnnotation(resourceType, guid, key, value string) (err error) {
+
+	payload := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				key: value,
+			},
+		},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	resource := CCMetadataResource{}
+	path := fmt.Sprintf("/v3/%s/%s", resourceType, guid)
+	return mm.patchResource(mm.apiEndpoint, path, bytes.NewReader(body), &resource)
+}
+
+// RemoveAnnotation - removes a single V3 annotation from the given resource
+func (mm *MetadataManager) RemoveAnnotation(resourceType, guid, key string) (err error) {
+
+	payload := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]*string{
+				key: nil,
+			},
+		},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	resource := CCMetadataResource{}
+	path := fmt.Sprintf("/v3/%s/%s", resourceType, guid)
+	return mm.patchResource(mm.apiEndpoint, path, bytes.NewReader(body), &resource)
+}
+
+// GetAnnotation - reads a single V3 annotation from the given resource
+func (mm *MetadataManager) GetAnnotation(resourceType, guid, key string) (value string, found bool, err error) {
+
+	resource := CCMetadataResource{}
+	path := fmt.Sprintf("%s/v3/%s/%s", mm.apiEndpoint, resourceType, guid)
+	if err = mm.ccGateway.GetResource(path, &resource); err != nil {
+		return "", false, err
+	}
+
+	v, ok := resource.Metadata.Annotations[key]
+	if !ok || v == nil {
+		return "", false, nil
+	}
+	return *v, true, nil
+}
+
+type ccV3ResourceList struct {
+	Pagination struct {
+		Next *struct {
+			Href string `json:"href"`
+		} `json:"next"`
+	} `json:"pagination"`
+	Resources []struct {
+		GUID string `json:"guid"`
+		Name string `json:"name"`
+	} `json:"resources"`
+}
+
+// ListByLabelSelector - returns a guid to name mapping of the resources in
+// the given V3 collection matching the label selector, optionally narrowed
+// by additional V3 list filters e.g. "organization_guids"
+func (mm *MetadataManager) ListByLabelSelector(resourceType, selector string, filters map[string]string) (matched map[string]string, err error) {
+
+	query := url.Values{}
+	query.Set("per_page", "100")
+	query.Set("label_selector", selector)
+	for k, v := range filters {
+		query.Set(k, v)
+	}
+
+	matched = make(map[string]string)
+	path := fmt.Sprintf("%s/v3/%s?%s", mm.apiEndpoint, resourceType, query.Encode())
+	for len(path) > 0 {
+		response := ccV3ResourceList{}
+		if err = mm.ccGateway.GetResource(path, &response); err != nil {
+			return nil, err
+		}
+		for _, r := range response.Resources {
+			matched[r.GUID] = r.Name
+		}
+		path = ""
+		if response.Pagination.Next != nil {
+			path = response.Pagination.Next.Href
+		}
+	}
+	return matched, nil
+}
+
+// SetManagedByAnnotation - marks the given resource as managed by this provider
+func (mm *MetadataManager) SetManagedByAnnotation(resourceType, guid string) (err error) {
+	return mm.SetAnnotation(resourceType, guid, AnnotationManagedBy, AnnotationManagedByValue)
+}
+
+// IsManagedByTerraform - checks whether the given resource carries the managed-by annotation
+func (mm *MetadataManager) IsManagedByTerraform(resourceType, guid string) (managed bool, err error) {
+
+	value, found, err := mm.GetAnnotation(resourceType, guid, AnnotationManagedBy)
+	if err != nil {
+		return false, err
+	}
+	return found && value == AnnotationManagedByValue, nil
+}
+
+// This one should belong to gateway.go, but that API is deprecated
+func (mm *MetadataManager) patchResource(endpoint, apiURL string, body io.ReadSeeker, optionalResource ...interface{}) error {
+	var resource interface{}
+	if len(optionalResource) > 0 {
+		resource = optionalResource[0]
+	}
+
+	request, err := mm.ccGateway.NewRequest("PATCH", endpoint+apiURL, mm.config.AccessToken(), body)
+	if err != nil {
+		return err
+	}
+
+	if resource == nil {
+		_, err = mm.ccGateway.PerformRequest(request)
+		return err
+	}
+
+	_, err = mm.ccGateway.PerformRequestForJSONResponse(request, resource)
+	return err
+}
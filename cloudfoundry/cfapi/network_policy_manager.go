@@ -0,0 +1,121 @@
+package cfapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/net"
+)
+
+// NetworkPolicyManager -
+type NetworkPolicyManager struct {
+	log *Logger
+
+	config    coreconfig.Reader
+	ccGateway net.Gateway
+
+	apiEndpoint string
+}
+
+// CCNetworkPolicy -
+type CCNetworkPolicy struct {
+	Source struct {
+		ID string `json:"id"`
+	} `json:"source"`
+
+	Destination struct {
+		ID       string `json:"id"`
+		Protocol string `json:"protocol"`
+		Ports    struct {
+			Start int `json:"start"`
+			End   int `json:"end"`
+		} `json:"ports"`
+	} `json:"destination"`
+}
+
+// ccNetworkPolicyList -
+type ccNetworkPolicyList struct {
+	Policies []CCNetworkPolicy `json:"policies"`
+}
+
+// NewNetworkPolicy - builds a policy for the given source/destination app pair
+func NewNetworkPolicy(sourceID string, destinationID string, protocol string, startPort int, endPort int) (p CCNetworkPolicy) {
+	p.Source.ID = sourceID
+	p.Destination.ID = destinationID
+	p.Destination.Protocol = protocol
+	p.Destination.Ports.Start = startPort
+	p.Destination.Ports.End = endPort
+	return p
+}
+
+// newNetworkPolicyManager -
+func newNetworkPolicyManager(config coreconfig.Reader, ccGateway net.Gateway, logger *Logger) (nm *NetworkPolicyManager, err error) {
+
+	nm = &NetworkPolicyManager{
+		log:         logger,
+		config:      config,
+		ccGateway:   ccGateway,
+		apiEndpoint: config.APIEndpoint(),
+	}
+
+	if len(nm.apiEndpoint) == 0 {
+		return nil, errors.New("API endpoint missing from config file")
+	}
+
+	return nm, nil
+}
+
+// ListPolicies - lists container networking policies, optionally restricted
+// to those involving the given app IDs
+func (nm *NetworkPolicyManager) ListPolicies(appIDs ...string) (policies []CCNetworkPolicy, err error) {
+
+	path := fmt.Sprintf("%s/networking/v1/external/policies", nm.apiEndpoint)
+	if len(appIDs) > 0 {
+		filter := appIDs[0]
+		for _, id := range appIDs[1:] {
+			filter = filter + "," + id
+		}
+		path = fmt.Sprintf("%s?id=%s", path, filter)
+	}
+
+	list := ccNetworkPolicyList{}
+	if err = nm.ccGateway.GetResource(path, &list); err != nil {
+		return nil, err
+	}
+	return list.Policies, nil
+}
+
+// AddPolicies - creates the given container networking policies
+func (nm *NetworkPolicyManager) AddPolicies(policies []CCNetworkPolicy) (err error) {
+	return nm.postPolicies("/networking/v1/external/policies", policies)
+}
+
+// RemovePolicies - deletes the given container networking policies
+func (nm *NetworkPolicyManager) RemovePolicies(policies []CCNetworkPolicy) (err error) {
+	return nm.postPolicies("/networking/v1/external/policies/delete", policies)
+}
+
+// postPolicies -
+func (nm *NetworkPolicyManager) postPolicies(path string, policies []CCNetworkPolicy) (err error) {
+
+	if len(policies) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(ccNetworkPolicyList{Policies: policies})
+	if err != nil {
+		return err
+	}
+
+	request, err := nm.ccGateway.NewRequest("POST",
+		fmt.Sprintf("%s%s", nm.apiEndpoint, path),
+		nm.config.AccessToken(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	_, err = nm.ccGateway.PerformRequest(request)
+	return err
+}
@@ -0,0 +1,271 @@
+package cfapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/net"
+)
+
+// QuotaType - Represents type of quota
+type QuotaType int
+
+const (
+	// SpaceQuota - Represents quota space
+	SpaceQuota QuotaType = 0
+	// OrgQuota - Represents org quota type
+	OrgQuota QuotaType = 1
+)
+
+// QuotaManager -
+type QuotaManager struct {
+	log         *Logger
+	config      coreconfig.Reader
+	ccGateway   net.Gateway
+	apiEndpoint string
+}
+
+// CCQuota -
+type CCQuota struct {
+	ID                      string
+	OrgGUID                 string `json:"organization_guid,omitempty"`
+	Name                    string `json:"name"`
+	AppInstanceLimit        int    `json:"app_instance_limit"`
+	AppTaskLimit            int    `json:"app_task_limit"`
+	InstanceMemoryLimit     int64  `json:"instance_memory_limit"`
+	MemoryLimit             int64  `json:"memory_limit"`
+	NonBasicServicesAllowed bool   `json:"non_basic_services_allowed"`
+	TotalServices           int    `json:"total_services"`
+	TotalServiceKeys        int    `json:"total_service_keys"`
+	TotalRoutes             int    `json:"total_routes"`
+	TotalReserveredPorts    int    `json:"total_reserved_route_ports"`
+	TotalPrivateDomains     int    `json:"total_private_domains,omitempty"`
+}
+
+// CCQuotaResource -
+type CCQuotaResource struct {
+	Metadata resources.Metadata `json:"metadata"`
+	Entity   CCQuota            `json:"entity"`
+}
+
+// NewQuotaManager -
+func newQuotaManager(config coreconfig.Reader, ccGateway net.Gateway, logger *Logger) (dm *QuotaManager, err error) {
+	dm = &QuotaManager{
+		log:         logger,
+		config:      config,
+		ccGateway:   ccGateway,
+		apiEndpoint: config.APIEndpoint(),
+	}
+
+	if len(dm.apiEndpoint) == 0 {
+		return nil, fmt.Errorf("API endpoint missing from config file")
+	}
+
+	return dm, nil
+}
+
+// getQuotaPath -
+func getQuotaPath(t QuotaType) string {
+	if t == SpaceQuota {
+		return "/v2/space_quota_definitions"
+	}
+	return "/v2/quota_definitions"
+}
+
+// CreateQuota -
+func (qm *QuotaManager) CreateQuota(t QuotaType, quota CCQuota) (id string, err error) {
+	body, err := json.Marshal(quota)
+	if err != nil {
+		return
+	}
+	path := getQuotaPath(t)
+	resource := CCQuotaResource{}
+	err = qm.ccGateway.CreateResource(qm.apiEndpoint, path, bytes.NewReader(body), &resource)
+	if err != nil {
+		return "", err
+	}
+	id = resource.Metadata.GUID
+	return id, nil
+}
+
+// UpdateQuota -
+func (qm *QuotaManager) UpdateQuota(t QuotaType, quota CCQuota) (err error) {
+	body, err := json.Marshal(quota)
+	if err != nil {
+		return err
+	}
+	path := fmt.Sprintf("%s/%s", getQuotaPath(t), quota.ID)
+	resource := CCQuotaResource{}
+	return qm.ccGateway.UpdateResource(qm.apiEndpoint, path, bytes.NewReader(body), &resource)
+}
+
+// ReadQuota -
+func (qm *QuotaManager) ReadQuota(t QuotaType, id string) (quota CCQuota, err error) {
+	resource := CCQuotaResource{}
+	url := fmt.Sprintf("%s%s/%s", qm.apiEndpoint, getQuotaPath(t), id)
+	if err = qm.ccGateway.GetResource(url, &resource); err != nil {
+		return CCQuota{}, err
+	}
+	quota = resource.Entity
+	quota.ID = resource.Metadata.GUID
+	return quota, nil
+}
+
+// GetOrgMemoryUsage - returns the memory in MB currently allocated to
+// started app instances in the given org
+func (qm *QuotaManager) GetOrgMemoryUsage(orgID string) (usage int64, err error) {
+	response := struct {
+		MemoryUsageInMB int64 `json:"memory_usage_in_mb"`
+	}{}
+	url := fmt.Sprintf("%s/v2/organizations/%s/memory_usage", qm.apiEndpoint, orgID)
+	if err = qm.ccGateway.GetResource(url, &response); err != nil {
+		return 0, err
+	}
+	return response.MemoryUsageInMB, nil
+}
+
+// CCQuotaUsage - point in time usage of quota governed resources in an
+// org or space
+type CCQuotaUsage struct {
+	MemoryInUse          int64
+	RoutesUsed           int
+	ServiceInstancesUsed int
+}
+
+// GetOrgUsage - returns the current usage of quota governed resources in
+// the given org
+func (qm *QuotaManager) GetOrgUsage(orgID string) (usage CCQuotaUsage, err error) {
+
+	if usage.MemoryInUse, err = qm.GetOrgMemoryUsage(orgID); err != nil {
+		return usage, err
+	}
+	if usage.RoutesUsed, err = qm.countResources(
+		fmt.Sprintf("/v2/routes?q=organization_guid:%s&results-per-page=1", orgID)); err != nil {
+		return usage, err
+	}
+	usage.ServiceInstancesUsed, err = qm.countResources(
+		fmt.Sprintf("/v2/service_instances?q=organization_guid:%s&results-per-page=1", orgID))
+	return usage, err
+}
+
+// GetSpaceUsage - returns the current usage of quota governed resources in
+// the given space
+func (qm *QuotaManager) GetSpaceUsage(spaceID string) (usage CCQuotaUsage, err error) {
+
+	summary := struct {
+		Apps []struct {
+			State     string `json:"state"`
+			Memory    int64  `json:"memory"`
+			Instances int64  `json:"instances"`
+		} `json:"apps"`
+	}{}
+	url := fmt.Sprintf("%s/v2/spaces/%s/summary", qm.apiEndpoint, spaceID)
+	if err = qm.ccGateway.GetResource(url, &summary); err != nil {
+		return usage, err
+	}
+	for _, app := range summary.Apps {
+		if app.State == "STARTED" {
+			usage.MemoryInUse += app.Memory * app.Instances
+		}
+	}
+	if usage.RoutesUsed, err = qm.countResources(
+		fmt.Sprintf("/v2/spaces/%s/routes?results-per-page=1", spaceID)); err != nil {
+		return usage, err
+	}
+	usage.ServiceInstancesUsed, err = qm.countResources(
+		fmt.Sprintf("/v2/spaces/%s/service_instances?results-per-page=1", spaceID))
+	return usage, err
+}
+
+// countResources - total number of resources behind a paginated CC endpoint
+func (qm *QuotaManager) countResources(path string) (count int, err error) {
+	response := struct {
+		TotalResults int `json:"total_results"`
+	}{}
+	if err = qm.ccGateway.GetResource(qm.apiEndpoint+path, &response); err != nil {
+		return 0, err
+	}
+	return response.TotalResults, nil
+}
+
+// DeleteQuota -
+func (qm *QuotaManager) DeleteQuota(t QuotaType, id string) (err error) {
+	path := fmt.Sprintf("%s/%s", getQuotaPath(t), id)
+	return qm.ccGateway.DeleteResource(qm.apiEndpoint, path)
+}
+
+// AssignQuota - assigns the quota to the given org or space
+func (qm *QuotaManager) AssignQuota(t QuotaType, quotaID string, targetID string) (err error) {
+
+	if t == SpaceQuota {
+		path := fmt.Sprintf("%s/v2/space_quota_definitions/%s/spaces/%s", qm.apiEndpoint, quotaID, targetID)
+		request, err := qm.ccGateway.NewRequest("PUT", path, qm.config.AccessToken(), nil)
+		if err != nil {
+			return err
+		}
+		_, err = qm.ccGateway.PerformRequest(request)
+		return err
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"quota_definition_guid": quotaID,
+	})
+	if err != nil {
+		return err
+	}
+	path := fmt.Sprintf("%s/v2/organizations/%s", qm.apiEndpoint, targetID)
+	request, err := qm.ccGateway.NewRequest("PUT", path, qm.config.AccessToken(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	_, err = qm.ccGateway.PerformRequest(request)
+	return err
+}
+
+// UnassignQuota - removes the quota assignment from the given org or space;
+// orgs always carry a quota, so the org quota named by fallbackQuota is
+// assigned in its place
+func (qm *QuotaManager) UnassignQuota(t QuotaType, quotaID string, targetID string, fallbackQuota string) (err error) {
+
+	if t == SpaceQuota {
+		path := fmt.Sprintf("/v2/space_quota_definitions/%s/spaces/%s", quotaID, targetID)
+		return qm.ccGateway.DeleteResource(qm.apiEndpoint, path)
+	}
+
+	defaultQuota, err := qm.FindQuotaByName(OrgQuota, fallbackQuota, nil)
+	if err != nil {
+		return fmt.Errorf(
+			"unable to find the fallback org quota '%s' to assign in place of the removed one: %s",
+			fallbackQuota, err.Error())
+	}
+	return qm.AssignQuota(OrgQuota, defaultQuota.ID, targetID)
+}
+
+// FindQuotaByName -
+func (qm *QuotaManager) FindQuotaByName(t QuotaType, name string, org *string) (quota CCQuota, err error) {
+	found := false
+	if qm.ccGateway.ListPaginatedResources(qm.apiEndpoint, getQuotaPath(t), CCQuotaResource{},
+		func(resource interface{}) bool {
+			quotaResource := resource.(CCQuotaResource)
+			if (org != nil) && (quotaResource.Entity.OrgGUID != *org) {
+				return true
+			}
+			if quotaResource.Entity.Name == name {
+				found = true
+				quota = quotaResource.Entity
+				quota.ID = quotaResource.Metadata.GUID
+				return false
+			}
+			return true
+		}); err != nil {
+		return CCQuota{}, err
+	}
+	if !found {
+		return CCQuota{}, errors.NewModelNotFoundError("Quota", name)
+	}
+	return quota, nil
+}
@@ -0,0 +1,45 @@
+package cfapi
+
+import (
+	"strings"
+	"time"
+)
+
+// DeleteRetryTimeout - how long deletes are retried while the Cloud
+// Controller reports an association error, e.g. because a broker is still
+// unbinding asynchronously. Settable by embedders before sessions are used.
+var DeleteRetryTimeout = 2 * time.Minute
+
+// DeleteRetryInterval - wait between delete retries
+var DeleteRetryInterval = 5 * time.Second
+
+// isAssociationError - true when the Cloud Controller rejected the request
+// because another object is still associated with the resource (HTTP
+// 400/409 with an association error), which usually resolves itself once
+// the asynchronous unbind or deprovision has finished
+func isAssociationError(err error) bool {
+
+	message := strings.ToLower(err.Error())
+	if !strings.Contains(message, "status code: 400") && !strings.Contains(message, "status code: 409") {
+		return false
+	}
+	return strings.Contains(message, "association") ||
+		strings.Contains(message, "10006") // CF-AssociationNotEmpty
+}
+
+// deleteWithRetry - runs the given delete, retrying association errors
+// until they resolve or DeleteRetryTimeout elapses
+func deleteWithRetry(log *Logger, description string, delete func() error) (err error) {
+
+	deadline := time.Now().Add(DeleteRetryTimeout)
+	for {
+		if err = delete(); err == nil || !isAssociationError(err) {
+			return err
+		}
+		if time.Now().After(deadline) {
+			return err
+		}
+		log.DebugMessage("Retrying delete of %s after association error: %s", description, err.Error())
+		time.Sleep(DeleteRetryInterval)
+	}
+}
@@ -33,6 +33,8 @@ type CCRoute struct {
 	Hostname   *string `json:"host,omitempty"`
 	Port       *int    `json:"port,omitempty"`
 	Path       *string `json:"path,omitempty"`
+
+	ServiceInstanceGUID *string `json:"service_instance_guid,omitempty"`
 }
 
 // CCRouteResource -
@@ -98,10 +100,10 @@ func (rm *RouteManager) FindRoute(
 	if err = rm.ccGateway.ListPaginatedResources(rm.apiEndpoint, apiPath, CCRouteResource{},
 		func(resource interface{}) bool {
 			routeResource := resource.(CCRouteResource)
-			if path != nil && path != routeResource.Entity.Path {
+			if path != nil && (routeResource.Entity.Path == nil || *path != *routeResource.Entity.Path) {
 				return true
 			}
-			if port != nil && port != routeResource.Entity.Port {
+			if port != nil && (routeResource.Entity.Port == nil || *port != *routeResource.Entity.Port) {
 				return true
 			}
 			domainResource := CCDomainResource{}
@@ -134,6 +136,38 @@ func (rm *RouteManager) FindRoute(
 	return route, nil
 }
 
+// FindRouteInDomain - looks up a route by hostname within the given domain,
+// which unlike FindRoute also works for routes on private domains
+func (rm *RouteManager) FindRouteInDomain(domainGUID string, hostname string) (route CCRoute, err error) {
+
+	apiPath := "/v2/routes"
+	if len(hostname) > 0 {
+		apiPath = "/v2/routes?q=host:" + hostname
+	}
+
+	if err = rm.ccGateway.ListPaginatedResources(rm.apiEndpoint, apiPath, CCRouteResource{},
+		func(resource interface{}) bool {
+			routeResource := resource.(CCRouteResource)
+			if routeResource.Entity.DomainGUID != domainGUID {
+				return true
+			}
+			if len(hostname) == 0 &&
+				routeResource.Entity.Hostname != nil && len(*routeResource.Entity.Hostname) > 0 {
+				return true
+			}
+			route = routeResource.Entity
+			route.ID = routeResource.Metadata.GUID
+			return false
+		}); err != nil {
+		return CCRoute{}, err
+	}
+
+	if len(route.ID) == 0 {
+		return CCRoute{}, errors.NewModelNotFoundError("Route", hostname)
+	}
+	return route, nil
+}
+
 // ReadRoute -
 func (rm *RouteManager) ReadRoute(routeID string) (route CCRoute, err error) {
 	resource := CCRouteResource{}
@@ -195,7 +229,9 @@ func (rm *RouteManager) UpdateRoute(r CCRoute) (route CCRoute, err error) {
 
 // DeleteRoute -
 func (rm *RouteManager) DeleteRoute(routeID string) (err error) {
-	return rm.ccGateway.DeleteResource(rm.apiEndpoint, fmt.Sprintf("/v2/routes/%s", routeID))
+	return deleteWithRetry(rm.log, "route "+routeID, func() error {
+		return rm.ccGateway.DeleteResource(rm.apiEndpoint, fmt.Sprintf("/v2/routes/%s", routeID))
+	})
 }
 
 // CreateRouteMapping -
@@ -278,7 +314,35 @@ func (rm *RouteManager) readRouteMappings(id, key string) (mappings []map[string
 	return mappings, err
 }
 
+// CCRouteDestination -
+type CCRouteDestination struct {
+	GUID string `json:"guid"`
+	App  struct {
+		GUID string `json:"guid"`
+	} `json:"app"`
+	Port   int  `json:"port"`
+	Weight *int `json:"weight"`
+}
+
+type ccRouteDestinationList struct {
+	Destinations []CCRouteDestination `json:"destinations"`
+}
+
+// ReadRouteDestinations - returns the V3 destinations of the given route,
+// which carry the per-app routing weights not exposed by the V2 mappings
+func (rm *RouteManager) ReadRouteDestinations(routeID string) (destinations []CCRouteDestination, err error) {
+
+	response := ccRouteDestinationList{}
+	path := fmt.Sprintf("%s/v3/routes/%s/destinations", rm.apiEndpoint, routeID)
+	if err = rm.ccGateway.GetResource(path, &response); err != nil {
+		return nil, err
+	}
+	return response.Destinations, nil
+}
+
 // DeleteRouteMapping -
 func (rm *RouteManager) DeleteRouteMapping(mappingID string) (err error) {
-	return rm.ccGateway.DeleteResource(rm.apiEndpoint, fmt.Sprintf("/v2/route_mappings/%s", mappingID))
+	return deleteWithRetry(rm.log, "route mapping "+mappingID, func() error {
+		return rm.ccGateway.DeleteResource(rm.apiEndpoint, fmt.Sprintf("/v2/route_mappings/%s", mappingID))
+	})
 }
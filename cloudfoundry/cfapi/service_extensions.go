@@ -0,0 +1,159 @@
+package cfapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// ServiceInstanceSnapshot - a snapshot/backup of a service instance created
+// through the OSB generic extensions backup endpoint of the owning broker
+type ServiceInstanceSnapshot struct {
+	ID          string `json:"backup_id"`
+	State       string `json:"state"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// ReadServiceBrokerForInstance - resolves the service broker owning the
+// given service instance
+func (s *Session) ReadServiceBrokerForInstance(serviceInstanceID string) (broker CCServiceBroker, err error) {
+
+	sm := s.serviceManager
+
+	instance, err := sm.ReadServiceInstance(serviceInstanceID)
+	if err != nil {
+		return broker, err
+	}
+	plan, err := sm.ReadServicePlan(instance.ServicePlanGUID)
+	if err != nil {
+		return broker, err
+	}
+	serviceResource := CCServiceResource{}
+	url := fmt.Sprintf("%s/v2/services/%s", sm.apiEndpoint, plan.ServiceGUID)
+	if err = sm.ccGateway.GetResource(url, &serviceResource); err != nil {
+		return broker, err
+	}
+	return sm.ReadServiceBroker(serviceResource.Entity.ServiceBrokerGUID)
+}
+
+// CreateServiceInstanceSnapshot - triggers a snapshot of the given service
+// instance via the broker's generic extensions backup endpoint; the given
+// credentials override the ones on the broker record when set, as the cloud
+// controller does not return broker passwords
+func (s *Session) CreateServiceInstanceSnapshot(
+	serviceInstanceID string,
+	username string,
+	password string,
+	params map[string]interface{}) (snapshot ServiceInstanceSnapshot, err error) {
+
+	broker, err := s.ReadServiceBrokerForInstance(serviceInstanceID)
+	if err != nil {
+		return snapshot, err
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"parameters": params})
+	if err != nil {
+		return snapshot, err
+	}
+	return s.brokerExtensionRequest("POST",
+		serviceInstanceSnapshotURL(broker, serviceInstanceID, ""),
+		broker, username, password, bytes.NewReader(body))
+}
+
+// ReadServiceInstanceSnapshot - reads the state of a previously triggered
+// snapshot from the broker's generic extensions backup endpoint
+func (s *Session) ReadServiceInstanceSnapshot(
+	serviceInstanceID string,
+	snapshotID string,
+	username string,
+	password string) (snapshot ServiceInstanceSnapshot, err error) {
+
+	broker, err := s.ReadServiceBrokerForInstance(serviceInstanceID)
+	if err != nil {
+		return snapshot, err
+	}
+	return s.brokerExtensionRequest("GET",
+		serviceInstanceSnapshotURL(broker, serviceInstanceID, snapshotID),
+		broker, username, password, nil)
+}
+
+// DeleteServiceInstanceSnapshot - deletes a snapshot via the broker's
+// generic extensions backup endpoint
+func (s *Session) DeleteServiceInstanceSnapshot(
+	serviceInstanceID string,
+	snapshotID string,
+	username string,
+	password string) (err error) {
+
+	broker, err := s.ReadServiceBrokerForInstance(serviceInstanceID)
+	if err != nil {
+		return err
+	}
+	_, err = s.brokerExtensionRequest("DELETE",
+		serviceInstanceSnapshotURL(broker, serviceInstanceID, snapshotID),
+		broker, username, password, nil)
+	return err
+}
+
+// serviceInstanceSnapshotURL - the generic extensions backup endpoint for
+// the given service instance on the given broker
+func serviceInstanceSnapshotURL(broker CCServiceBroker, serviceInstanceID, snapshotID string) string {
+
+	url := fmt.Sprintf("%s/v2/service_instances/%s/extensions/backups",
+		strings.TrimSuffix(broker.BrokerURL, "/"), serviceInstanceID)
+	if len(snapshotID) > 0 {
+		url = url + "/" + snapshotID
+	}
+	return url
+}
+
+// brokerExtensionRequest - performs an authenticated request against a
+// broker extension endpoint, outside of the cloud controller API
+func (s *Session) brokerExtensionRequest(
+	method string,
+	url string,
+	broker CCServiceBroker,
+	username string,
+	password string,
+	body io.Reader) (snapshot ServiceInstanceSnapshot, err error) {
+
+	if len(username) == 0 {
+		username = broker.AuthUserName
+		password = broker.AuthPassword
+	}
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return snapshot, err
+	}
+	req.SetBasicAuth(username, password)
+	req.Header.Set("X-Broker-API-Version", "2.14")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return snapshot, err
+	}
+	defer resp.Body.Close()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return snapshot, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return snapshot, fmt.Errorf(
+			"broker extension request %s %s failed, status code: %d, body: %s",
+			method, url, resp.StatusCode, raw)
+	}
+	if len(raw) > 0 {
+		err = json.Unmarshal(raw, &snapshot)
+	}
+	return snapshot, err
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"sync"
 
 	"code.cloudfoundry.org/cli/cf/api"
 	"code.cloudfoundry.org/cli/cf/api/resources"
@@ -26,6 +27,9 @@ type ServiceManager struct {
 
 	repo   api.ServiceRepository
 	sbRepo api.ServiceBrokerRepository
+
+	brokerNamesMutex sync.Mutex
+	brokerNames      map[string]string
 }
 
 // CCService -
@@ -66,10 +70,14 @@ type CCServicePlan struct {
 
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	ServiceGUID string `json:"service_guid,omitempty"`
 
 	Free   bool `json:"free"`
 	Public bool `json:"public"`
 	Active bool `json:"active"`
+
+	// Schemas - the OSB parameter schemas the broker publishes for the plan
+	Schemas map[string]interface{} `json:"schemas,omitempty"`
 }
 
 // CCServicePlanResource -
@@ -100,6 +108,8 @@ type CCServiceBrokerResource struct {
 
 // CCServiceInstance -
 type CCServiceInstance struct {
+	ID string
+
 	Name            string                 `json:"name"`
 	SpaceGUID       string                 `json:"space_guid"`
 	ServicePlanGUID string                 `json:"service_plan_guid"`
@@ -180,9 +190,42 @@ func newServiceManager(config coreconfig.Reader, ccGateway net.Gateway, logger *
 		apiEndpoint: config.APIEndpoint(),
 		repo:        api.NewCloudControllerServiceRepository(config, ccGateway),
 		sbRepo:      api.NewCloudControllerServiceBrokerRepository(config, ccGateway),
+		brokerNames: make(map[string]string),
 	}, nil
 }
 
+// ServiceBrokerNameForPlan - resolves the name of the broker serving the
+// given service plan, caching the result as the plan to broker relationship
+// never changes within a session
+func (sm *ServiceManager) ServiceBrokerNameForPlan(servicePlanID string) (name string, err error) {
+
+	sm.brokerNamesMutex.Lock()
+	name, found := sm.brokerNames[servicePlanID]
+	sm.brokerNamesMutex.Unlock()
+	if found {
+		return name, nil
+	}
+
+	plan, err := sm.ReadServicePlan(servicePlanID)
+	if err != nil {
+		return "", err
+	}
+	serviceResource := CCServiceResource{}
+	url := fmt.Sprintf("%s/v2/services/%s", sm.apiEndpoint, plan.ServiceGUID)
+	if err = sm.ccGateway.GetResource(url, &serviceResource); err != nil {
+		return "", err
+	}
+	serviceBroker, err := sm.ReadServiceBroker(serviceResource.Entity.ServiceBrokerGUID)
+	if err != nil {
+		return "", err
+	}
+
+	sm.brokerNamesMutex.Lock()
+	sm.brokerNames[servicePlanID] = serviceBroker.Name
+	sm.brokerNamesMutex.Unlock()
+	return serviceBroker.Name, nil
+}
+
 // ReadServiceInfo -
 func (sm *ServiceManager) ReadServiceInfo(serviceBrokerID string) (services []CCService, err error) {
 	path := fmt.Sprintf("/v2/services?q=service_broker_guid:%s", serviceBrokerID)
@@ -495,6 +538,21 @@ func (sm *ServiceManager) UpdateServiceInstance(
 	return serviceInstance, nil
 }
 
+// RenameServiceInstance - updates only the name of a service instance; the
+// Cloud Controller applies renames synchronously so no operation needs to be
+// polled afterwards
+func (sm *ServiceManager) RenameServiceInstance(serviceInstanceID, name string) (err error) {
+
+	path := fmt.Sprintf("/v2/service_instances/%s", serviceInstanceID)
+	jsonBytes, err := json.Marshal(map[string]string{"name": name})
+	if err != nil {
+		return err
+	}
+
+	resource := CCServiceInstanceResource{}
+	return sm.ccGateway.UpdateResource(sm.apiEndpoint, path, bytes.NewReader(jsonBytes), &resource)
+}
+
 // ReadServiceInstance -
 func (sm *ServiceManager) ReadServiceInstance(serviceInstanceID string) (serviceInstance CCServiceInstance, err error) {
 	path := fmt.Sprintf("%s/v2/service_instances/%s", sm.apiEndpoint, serviceInstanceID)
@@ -504,6 +562,7 @@ func (sm *ServiceManager) ReadServiceInstance(serviceInstanceID string) (service
 		return CCServiceInstance{}, err
 	}
 	serviceInstance = resource.Entity
+	serviceInstance.ID = resource.Metadata.GUID
 	return serviceInstance, nil
 }
 
@@ -521,6 +580,7 @@ func (sm *ServiceManager) FindServiceInstance(name string, spaceID string) (serv
 		CCServiceInstanceResource{},
 		func(resource interface{}) bool {
 			if sp, ok := resource.(CCServiceInstanceResource); ok {
+				sp.Entity.ID = sp.Metadata.GUID
 				serviceInstance = sp.Entity // there should 1 or 0 instances in the space with that name
 				found = true
 				return false
@@ -757,6 +817,55 @@ func (sm *ServiceManager) FindServiceByName(label string) (offering models.Servi
 	return offering, err
 }
 
+// ListServiceOfferings -
+func (sm *ServiceManager) ListServiceOfferings(spaceID string) (services []CCService, err error) {
+
+	path := "/v2/services"
+	if len(spaceID) > 0 {
+		path = fmt.Sprintf("/v2/spaces/%s/services", spaceID)
+	}
+
+	err = sm.ccGateway.ListPaginatedResources(
+		sm.apiEndpoint,
+		path,
+		CCServiceResource{},
+		func(resource interface{}) bool {
+			if s, ok := resource.(CCServiceResource); ok {
+				s.Entity.ID = s.Metadata.GUID
+				services = append(services, s.Entity)
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range services {
+		if services[i].ServicePlans, err = sm.listServicePlans(services[i].ID); err != nil {
+			return nil, err
+		}
+	}
+	return services, nil
+}
+
+// listServicePlans -
+func (sm *ServiceManager) listServicePlans(serviceID string) (plans []CCServicePlan, err error) {
+
+	err = sm.ccGateway.ListPaginatedResources(
+		sm.apiEndpoint,
+		fmt.Sprintf("/v2/services/%s/service_plans", serviceID),
+		CCServicePlanResource{},
+		func(resource interface{}) bool {
+			if sp, ok := resource.(CCServicePlanResource); ok {
+				sp.Entity.ID = sp.Metadata.GUID
+				plans = append(plans, sp.Entity)
+			}
+			return true
+		})
+
+	return plans, err
+}
+
 // GetServicePlans -
 func (sm *ServiceManager) GetServicePlans(serviceID string) (servicePlans map[string]interface{}, err error) {
 
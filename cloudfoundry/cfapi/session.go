@@ -39,6 +39,52 @@ type Session struct {
 	segmentManager   *SegmentManager
 	appManager       *AppManager
 	routeManager     *RouteManager
+	metadataManager  *MetadataManager
+
+	networkPolicyManager *NetworkPolicyManager
+
+	// EnforceManagedBy - when true resources not carrying the
+	// terraform.io/managed-by annotation are refused for modification
+	EnforceManagedBy bool
+
+	// DefaultAppTimeout - provider wide default wait (in seconds) for app
+	// instance startup, applied when a resource does not set its own timeout
+	DefaultAppTimeout int
+
+	// DefaultStagingTimeout - provider wide default wait (in seconds) for
+	// staging operations, applied when a resource does not set its own timeout
+	DefaultStagingTimeout int
+
+	// DefaultServiceTimeout - provider wide default wait (in seconds) for
+	// asynchronous service instance operations, applied when a resource does
+	// not set its own timeouts
+	DefaultServiceTimeout int
+
+	// AppArtifactCache - directory where downloaded application artifacts
+	// are kept and reused across applies; empty disables caching
+	AppArtifactCache string
+
+	// WorkDir - directory used for artifact downloads and other scratch
+	// files; empty falls back to the OS temp dir
+	WorkDir string
+
+	// MaxUploadSizeMB - upper bound on the size of application artifacts
+	// uploaded to the Cloud Controller; 0 disables the check
+	MaxUploadSizeMB int
+
+	// NamePrefix / NameSuffix - affixes applied to the names of created
+	// apps, route hostnames and service instances for resources that opt in
+	// via apply_name_affixes
+	NamePrefix string
+	NameSuffix string
+
+	// ApprovedRouteDomains - when non-empty, routes may only be created on
+	// the listed domain names; enforced at plan time
+	ApprovedRouteDomains []string
+
+	// ServiceBrokerLimiter - caps concurrent service operations per broker
+	// according to the provider configuration; nil when no ceilings are set
+	ServiceBrokerLimiter *ServiceBrokerLimiter
 
 	// Used for direct endpoint calls
 	httpClient *http.Client
@@ -59,6 +105,8 @@ type CCInfo struct {
 	MinCLIVersion            string `json:"min_cli_version"`
 	MinRecommendedCLIVersion string `json:"min_recommended_cli_version"`
 	SSHOAuthClient           string `json:"app_ssh_oauth_client"`
+	AppSSHEndpoint           string `json:"app_ssh_endpoint"`
+	AppSSHHostKeyFingerprint string `json:"app_ssh_host_key_fingerprint"`
 	RoutingAPIEndpoint       string `json:"routing_endpoint"`
 }
 
@@ -227,10 +275,26 @@ func (s *Session) initCliConnection(
 		return err
 	}
 
+	s.metadataManager, err = newMetadataManager(s.config, s.ccGateway, s.Log)
+	if err != nil {
+		return err
+	}
+
+	s.networkPolicyManager, err = newNetworkPolicyManager(s.config, s.ccGateway, s.Log)
+	if err != nil {
+		return err
+	}
+
 	s.appManager, err = newAppManager(s.config, s.ccGateway, s.domainManager.repo, s.routeManager.repo, s.Log)
 	return err
 }
 
+// HTTPClient - a plain HTTP client honouring the provider's SSL settings,
+// for requests outside of the CC and UAA APIs
+func (s *Session) HTTPClient() *http.Client {
+	return s.httpClient
+}
+
 // Info -
 func (s *Session) Info() *CCInfo {
 	return &s.ccInfo
@@ -301,6 +365,16 @@ func (s *Session) SegmentManager() *SegmentManager {
 	return s.segmentManager
 }
 
+// MetadataManager -
+func (s *Session) MetadataManager() *MetadataManager {
+	return s.metadataManager
+}
+
+// NetworkPolicyManager -
+func (s *Session) NetworkPolicyManager() *NetworkPolicyManager {
+	return s.networkPolicyManager
+}
+
 // GetFeatureFlags -
 func (s *Session) GetFeatureFlags() (featurFlags map[string]bool, err error) {
 	featurFlags = make(map[string]bool)
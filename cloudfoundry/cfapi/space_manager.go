@@ -271,6 +271,37 @@ func (sm *SpaceManager) ListASGs(spaceID string) (asgIDs []interface{}, err erro
 	return asgIDs, nil
 }
 
+// ListSpaceResources - returns the GUIDs and names of all resources of the
+// given V2 space collection (e.g. "apps", "routes", "service_instances")
+func (sm *SpaceManager) ListSpaceResources(spaceID, collection string) (spaceResources map[string]string, err error) {
+
+	spaceResources = make(map[string]string)
+
+	path := fmt.Sprintf("/v2/spaces/%s/%s", spaceID, collection)
+	if collection == "service_instances" {
+		path = path + "?return_user_provided_service_instances=true"
+	}
+
+	resource := make(map[string]interface{})
+	err = sm.ccGateway.ListPaginatedResources(sm.apiEndpoint, path, resource, func(resource interface{}) bool {
+		spaceResource := resource.(map[string]interface{})
+		guid := spaceResource["metadata"].(map[string]interface{})["guid"].(string)
+
+		// routes carry a 'host' instead of a 'name'
+		name := ""
+		entity := spaceResource["entity"].(map[string]interface{})
+		if v, ok := entity["name"].(string); ok {
+			name = v
+		} else if v, ok := entity["host"].(string); ok {
+			name = v
+		}
+
+		spaceResources[guid] = name
+		return true
+	})
+	return spaceResources, err
+}
+
 // SetSpaceSegment -
 func (sm *SpaceManager) SetSpaceSegment(spaceID string, segmentID string) (err error) {
 	payload := struct {
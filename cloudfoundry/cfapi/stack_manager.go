@@ -39,6 +39,20 @@ func newStackManager(config coreconfig.Reader, ccGateway net.Gateway, logger *Lo
 	return sm, nil
 }
 
+// FindStack -
+func (sm *StackManager) FindStack(id string) (stack CCStack, err error) {
+
+	var s models.Stack
+	if s, err = sm.repo.FindByGUID(id); err != nil {
+		return CCStack{}, err
+	}
+
+	stack.ID = s.GUID
+	stack.Name = s.Name
+	stack.Description = s.Description
+	return stack, nil
+}
+
 // FindStackByName -
 func (sm *StackManager) FindStackByName(name string) (stack CCStack, err error) {
 
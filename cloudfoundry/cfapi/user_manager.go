@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"code.cloudfoundry.org/cli/cf/api"
 	"code.cloudfoundry.org/cli/cf/api/resources"
@@ -40,6 +41,9 @@ type UAAUser struct {
 	Name   UAAUserName    `json:"name,omitempty"`
 	Emails []UAAUserEmail `json:"emails,omitempty"`
 	Groups []UAAUserGroup `json:"groups,omitempty"`
+
+	// Verified - false for invited users until the invitation is accepted
+	Verified *bool `json:"verified,omitempty"`
 }
 
 // UAAUserEmail -
@@ -68,6 +72,16 @@ type UAAGroupResourceList struct {
 	} `json:"resources"`
 }
 
+// UAAIdentityProvider -
+type UAAIdentityProvider struct {
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name"`
+	OriginKey string          `json:"originKey"`
+	Type      string          `json:"type"`
+	Active    bool            `json:"active"`
+	Config    json.RawMessage `json:"config,omitempty"`
+}
+
 // CCUser -
 type CCUser struct {
 	ID string
@@ -251,6 +265,49 @@ func (um *UserManager) CreateUser(
 	return user, nil
 }
 
+// UAAInviteResponse -
+type UAAInviteResponse struct {
+	NewInvites []struct {
+		Email        string `json:"email"`
+		UserID       string `json:"userId"`
+		InviteLink   string `json:"inviteLink"`
+		Success      bool   `json:"success"`
+		ErrorMessage string `json:"errorMessage"`
+	} `json:"new_invites"`
+}
+
+// InviteUser - creates a UAA user in invited state and sends an invitation
+// email via the UAA invitations API, returning the new user's ID and the
+// invitation link
+func (um *UserManager) InviteUser(email string, redirectURI string) (userID string, inviteLink string, err error) {
+
+	uaaEndpoint := um.config.UaaEndpoint()
+	if len(uaaEndpoint) == 0 {
+		return "", "", errors.New("UAA endpoint missing from config file")
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"emails": []string{email},
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	path := fmt.Sprintf("/invite_users?redirect_uri=%s", url.QueryEscape(redirectURI))
+	response := UAAInviteResponse{}
+	if err = um.uaaGateway.CreateResource(uaaEndpoint, path, bytes.NewReader(body), &response); err != nil {
+		return "", "", err
+	}
+	if len(response.NewInvites) == 0 {
+		return "", "", fmt.Errorf("UAA did not accept the invitation for '%s'", email)
+	}
+	invite := response.NewInvites[0]
+	if !invite.Success {
+		return "", "", fmt.Errorf("inviting '%s' failed: %s", email, invite.ErrorMessage)
+	}
+	return invite.UserID, invite.InviteLink, nil
+}
+
 // UpdateUser -
 func (um *UserManager) UpdateUser(
 	id string,
@@ -412,3 +469,122 @@ func (um *UserManager) FindByUsername(username string) (models.UserFields, error
 func (um *UserManager) Delete(userID string) error {
 	return um.repo.Delete(userID)
 }
+
+// CreateIdentityProvider - creates a UAA identity provider
+func (um *UserManager) CreateIdentityProvider(provider UAAIdentityProvider) (created UAAIdentityProvider, err error) {
+
+	uaaEndpoint := um.config.UaaEndpoint()
+	if len(uaaEndpoint) == 0 {
+		return UAAIdentityProvider{}, errors.New("UAA endpoint missing from config file")
+	}
+
+	body, err := json.Marshal(provider)
+	if err != nil {
+		return UAAIdentityProvider{}, err
+	}
+
+	err = um.uaaGateway.CreateResource(uaaEndpoint, "/identity-providers", bytes.NewReader(body), &created)
+	return created, err
+}
+
+// GetIdentityProvider - retrieves a UAA identity provider by ID
+func (um *UserManager) GetIdentityProvider(id string) (provider UAAIdentityProvider, err error) {
+
+	uaaEndpoint := um.config.UaaEndpoint()
+	if len(uaaEndpoint) == 0 {
+		return UAAIdentityProvider{}, errors.New("UAA endpoint missing from config file")
+	}
+
+	err = um.uaaGateway.GetResource(fmt.Sprintf("%s/identity-providers/%s", uaaEndpoint, id), &provider)
+	return provider, err
+}
+
+// UpdateIdentityProvider - updates a UAA identity provider
+func (um *UserManager) UpdateIdentityProvider(id string, provider UAAIdentityProvider) (err error) {
+
+	uaaEndpoint := um.config.UaaEndpoint()
+	if len(uaaEndpoint) == 0 {
+		return errors.New("UAA endpoint missing from config file")
+	}
+
+	body, err := json.Marshal(provider)
+	if err != nil {
+		return err
+	}
+
+	request, err := um.uaaGateway.NewRequest("PUT",
+		fmt.Sprintf("%s/identity-providers/%s", uaaEndpoint, id),
+		um.config.AccessToken(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	_, err = um.uaaGateway.PerformRequest(request)
+	return err
+}
+
+// DeleteIdentityProvider - deletes a UAA identity provider
+func (um *UserManager) DeleteIdentityProvider(id string) (err error) {
+
+	uaaEndpoint := um.config.UaaEndpoint()
+	if len(uaaEndpoint) == 0 {
+		return errors.New("UAA endpoint missing from config file")
+	}
+
+	return um.uaaGateway.DeleteResource(uaaEndpoint, fmt.Sprintf("/identity-providers/%s", id))
+}
+
+// UAAUserResourceList -
+type UAAUserResourceList struct {
+	Resources []UAAUser `json:"resources"`
+}
+
+// FindUAAUserByName - resolves a UAA user by username, optionally scoped to
+// an origin when the username exists in multiple origins
+func (um *UserManager) FindUAAUserByName(username string, origin string) (user UAAUser, err error) {
+
+	uaaEndpoint := um.config.UaaEndpoint()
+	if len(uaaEndpoint) == 0 {
+		return UAAUser{}, errors.New("UAA endpoint missing from config file")
+	}
+
+	filter := fmt.Sprintf(`userName eq "%s"`, username)
+	if len(origin) > 0 {
+		filter = fmt.Sprintf(`%s and origin eq "%s"`, filter, origin)
+	}
+
+	userList := UAAUserResourceList{}
+	path := fmt.Sprintf("%s/Users?filter=%s", uaaEndpoint, url.QueryEscape(filter))
+	if err = um.uaaGateway.GetResource(path, &userList); err != nil {
+		return UAAUser{}, err
+	}
+
+	switch count := len(userList.Resources); {
+	case count < 1:
+		return UAAUser{}, errors.NewModelNotFoundError("User", username)
+	case count > 1:
+		return UAAUser{}, fmt.Errorf("more than one UAA user named '%s' found; provide an origin to disambiguate", username)
+	}
+	return userList.Resources[0], nil
+}
+
+// UAAClient -
+type UAAClient struct {
+	ClientID             string   `json:"client_id"`
+	Scope                []string `json:"scope"`
+	Authorities          []string `json:"authorities"`
+	AuthorizedGrantTypes []string `json:"authorized_grant_types"`
+	RedirectURI          []string `json:"redirect_uri"`
+}
+
+// GetClient - retrieves a UAA OAuth client by client ID
+func (um *UserManager) GetClient(clientID string) (client UAAClient, err error) {
+
+	uaaEndpoint := um.config.UaaEndpoint()
+	if len(uaaEndpoint) == 0 {
+		return UAAClient{}, errors.New("UAA endpoint missing from config file")
+	}
+
+	path := fmt.Sprintf("%s/oauth/clients/%s", uaaEndpoint, clientID)
+	err = um.uaaGateway.GetResource(path, &client)
+	return client, err
+}
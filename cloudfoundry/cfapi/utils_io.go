@@ -108,3 +108,19 @@ func copyDir(src string, dst string) (err error) {
 
 	return nil
 }
+
+// CopyResource copies the file or directory tree at src to dst, creating
+// parent directories of dst as needed.
+func CopyResource(src, dst string) (err error) {
+	sfi, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	if sfi.IsDir() {
+		return copyDir(src, dst)
+	}
+	return copyFile(src, dst)
+}
@@ -1,6 +1,10 @@
 package cloudfoundry
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
 
 // AppStatusStaging - CF status for running apps
 const AppStatusStaging = "staging"
@@ -13,6 +17,25 @@ type NewResourceMeta struct {
 	meta interface{}
 }
 
+// validateJSONString -
+func validateJSONString(v interface{}, k string) (ws []string, errs []error) {
+	if value := v.(string); len(value) > 0 {
+		var out interface{}
+		if err := json.Unmarshal([]byte(value), &out); err != nil {
+			errs = append(errs, fmt.Errorf("%q must be valid JSON: %s", k, err))
+		}
+	}
+	return ws, errs
+}
+
+// validateHHMMTime -
+func validateHHMMTime(v interface{}, k string) (ws []string, errs []error) {
+	if _, err := time.Parse("15:04", v.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("%q must be a time of day in 24h HH:MM format", k))
+	}
+	return ws, errs
+}
+
 // validateDefaultRunningStagingName -
 func validateDefaultRunningStagingName(v interface{}, k string) (ws []string, errs []error) {
 	value := v.(string)
@@ -11,9 +11,51 @@ type Config struct {
 	UaaClientSecret   string
 	CACert            string
 	SkipSslValidation bool
+	EnforceManagedBy  bool
+
+	DefaultAppTimeout     int
+	DefaultStagingTimeout int
+	DefaultServiceTimeout int
+
+	AppArtifactCache  string
+	WorkDir           string
+	MaxUploadSizeMB   int
+	NamePrefix        string
+	NameSuffix        string
+	BrokerConcurrency map[string]int
+
+	ApprovedRouteDomains []string
+	Simulate             bool
 }
 
 // Client - Terraform providor client initialization
 func (c *Config) Client() (*cfapi.Session, error) {
-	return cfapi.NewSession(c.endpoint, c.User, c.Password, c.UaaClientID, c.UaaClientSecret, c.CACert, c.SkipSslValidation)
+
+	var (
+		session *cfapi.Session
+		err     error
+	)
+	if c.Simulate {
+		// run against an in-process fake Cloud Controller so CI can validate
+		// the configuration without touching a real foundation; the fake
+		// lives for the remainder of the provider process
+		session, err = cfapi.NewFakeSession(cfapi.NewFakeCloudController())
+	} else {
+		session, err = cfapi.NewSession(c.endpoint, c.User, c.Password, c.UaaClientID, c.UaaClientSecret, c.CACert, c.SkipSslValidation)
+	}
+	if err != nil {
+		return nil, err
+	}
+	session.EnforceManagedBy = c.EnforceManagedBy
+	session.DefaultAppTimeout = c.DefaultAppTimeout
+	session.DefaultStagingTimeout = c.DefaultStagingTimeout
+	session.DefaultServiceTimeout = c.DefaultServiceTimeout
+	session.AppArtifactCache = c.AppArtifactCache
+	session.WorkDir = c.WorkDir
+	session.MaxUploadSizeMB = c.MaxUploadSizeMB
+	session.NamePrefix = c.NamePrefix
+	session.NameSuffix = c.NameSuffix
+	session.ServiceBrokerLimiter = cfapi.NewServiceBrokerLimiter(c.BrokerConcurrency)
+	session.ApprovedRouteDomains = c.ApprovedRouteDomains
+	return session, nil
 }
@@ -0,0 +1,75 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func dataSourceAppPortForwarding() *schema.Resource {
+
+	return &schema.Resource{
+
+		Read: dataSourceAppPortForwardingRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"app": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"instance": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+			"port": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  8080,
+			},
+			"local_port": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+			"local_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAppPortForwardingRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	appID := d.Get("app").(string)
+	instance := d.Get("instance").(int)
+	port := d.Get("port").(int)
+	localPort := d.Get("local_port").(int)
+
+	// the tunnel is deliberately left open; it lives for the remainder of
+	// the plan or apply so provisioners can reach the forwarded port, and is
+	// torn down when the provider process exits
+	tunnel, err := session.OpenAppSSHTunnel(appID, instance, port, localPort)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%d/%d", appID, instance, port))
+	d.Set("local_address", tunnel.LocalAddress)
+	if i := strings.LastIndex(tunnel.LocalAddress, ":"); i > 0 {
+		var p int
+		if _, err := fmt.Sscanf(tunnel.LocalAddress[i+1:], "%d", &p); err == nil {
+			d.Set("local_port", p)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package cloudfoundry
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func dataSourceAppRoutes() *schema.Resource {
+
+	return &schema.Resource{
+
+		Read: dataSourceAppRoutesRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"app": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"routes": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"route": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"mapping_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"port": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"weight": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAppRoutesRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	rm := session.RouteManager()
+	app := d.Get("app").(string)
+
+	mappings, err := rm.ReadRouteMappingsByApp(app)
+	if err != nil {
+		return err
+	}
+
+	var routes []map[string]interface{}
+	for _, mapping := range mappings {
+
+		route := map[string]interface{}{
+			"route":      mapping["route"],
+			"mapping_id": mapping["mapping_id"],
+		}
+		if port, ok := mapping["port"]; ok {
+			route["port"] = port
+		}
+
+		// routing weights only exist on V3 destinations, so failures here
+		// (e.g. older Cloud Controller versions) leave the weight unset
+		if destinations, err := rm.ReadRouteDestinations(mapping["route"].(string)); err == nil {
+			for _, destination := range destinations {
+				if destination.App.GUID == app && destination.Weight != nil {
+					route["weight"] = *destination.Weight
+				}
+			}
+		} else {
+			session.Log.DebugMessage("unable to read destinations of route %s : %s", mapping["route"], err.Error())
+		}
+
+		routes = append(routes, route)
+	}
+
+	d.SetId(app)
+	d.Set("routes", routes)
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package cloudfoundry
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func dataSourceBuildpacks() *schema.Resource {
+
+	return &schema.Resource{
+
+		Read: dataSourceBuildpacksRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"buildpacks": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"stack": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"position": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"enabled": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"filename": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBuildpacksRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	bpm := session.BuildpackManager()
+
+	installed, err := bpm.ListBuildpacks()
+	if err != nil {
+		return err
+	}
+
+	buildpacks := []interface{}{}
+	for _, bp := range installed {
+
+		buildpack := map[string]interface{}{
+			"id":       bp.ID,
+			"name":     bp.Name,
+			"stack":    bp.Stack,
+			"filename": bp.Filename,
+		}
+		if bp.Position != nil {
+			buildpack["position"] = *bp.Position
+		}
+		if bp.Enabled != nil {
+			buildpack["enabled"] = *bp.Enabled
+		}
+		buildpacks = append(buildpacks, buildpack)
+	}
+	d.Set("buildpacks", buildpacks)
+	d.SetId("buildpacks")
+
+	return nil
+}
@@ -36,6 +36,18 @@ func dataSourceDomain() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"router_group": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"router_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"internal": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -102,6 +114,9 @@ func dataSourceDomainRead(d *schema.ResourceData, meta interface{}) (err error)
 	d.Set("sub_domain", domainParts[0])
 	d.Set("domain", strings.Join(domainParts[1:], "."))
 	d.Set("org", domain.OwningOrganizationGUID)
+	d.Set("router_group", domain.RouterGroupGUID)
+	d.Set("router_type", domain.RouterType)
+	d.Set("internal", domain.Internal)
 	d.SetId(domain.ID)
 	return err
 }
@@ -0,0 +1,129 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func dataSourceDriftReport() *schema.Resource {
+
+	driftEntry := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+
+	return &schema.Resource{
+
+		Read: dataSourceDriftReportRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"space": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"managed_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      resourceStringHash,
+			},
+			"unmanaged_apps": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     driftEntry,
+			},
+			"unmanaged_routes": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     driftEntry,
+			},
+			"unmanaged_service_instances": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     driftEntry,
+			},
+		},
+	}
+}
+
+func dataSourceDriftReportRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	space := d.Get("space").(string)
+
+	managed := make(map[string]bool)
+	for _, v := range d.Get("managed_ids").(*schema.Set).List() {
+		managed[v.(string)] = true
+	}
+
+	for collection, attribute := range map[string]string{
+		"apps":              "unmanaged_apps",
+		"routes":            "unmanaged_routes",
+		"service_instances": "unmanaged_service_instances",
+	} {
+		unmanaged, err := driftReportUnmanagedResources(session, space, collection, managed)
+		if err != nil {
+			return err
+		}
+		d.Set(attribute, unmanaged)
+	}
+
+	d.SetId(space)
+	return nil
+}
+
+// driftReportUnmanagedResources - lists the resources of the given collection
+// in the space that are not accounted for, either because they are missing
+// from 'managed_ids' or, when no ids were given, because they do not carry
+// the terraform.io/managed-by annotation
+func driftReportUnmanagedResources(
+	session *cfapi.Session,
+	space, collection string,
+	managed map[string]bool) (unmanaged []map[string]interface{}, err error) {
+
+	spaceResources, err := session.SpaceManager().ListSpaceResources(space, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	for guid, name := range spaceResources {
+		if len(managed) > 0 {
+			if managed[guid] {
+				continue
+			}
+		} else {
+			isManaged, err := session.MetadataManager().IsManagedByTerraform(collection, guid)
+			if err != nil {
+				return nil, err
+			}
+			if isManaged {
+				continue
+			}
+		}
+		unmanaged = append(unmanaged, map[string]interface{}{
+			"id":   guid,
+			"name": name,
+		})
+	}
+
+	sort.Slice(unmanaged, func(i, j int) bool {
+		return unmanaged[i]["id"].(string) < unmanaged[j]["id"].(string)
+	})
+	return unmanaged, nil
+}
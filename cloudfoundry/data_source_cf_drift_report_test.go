@@ -0,0 +1,87 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+const driftReportDataResource = `
+
+data "cloudfoundry_org" "org" {
+    name = "pcfdev-org"
+}
+data "cloudfoundry_space" "space" {
+    name = "pcfdev-space"
+	org = "${data.cloudfoundry_org.org.id}"
+}
+
+resource "cloudfoundry_app" "managed" {
+	name = "test-app-drift"
+	space = "${data.cloudfoundry_space.space.id}"
+	memory = "512"
+	disk_quota = "512"
+	timeout = 1800
+	url = "file://../tests/cf-acceptance-tests/assets/java-spring/java-spring.jar"
+}
+
+data "cloudfoundry_drift_report" "space" {
+	space = "${data.cloudfoundry_space.space.id}"
+	managed_ids = [ "${cloudfoundry_app.managed.id}" ]
+}
+`
+
+func TestAccDataSourceDriftReport_normal(t *testing.T) {
+
+	ref := "data.cloudfoundry_drift_report.space"
+	appRef := "cloudfoundry_app.managed"
+
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+
+				resource.TestStep{
+					Config: driftReportDataResource,
+					Check: resource.ComposeTestCheckFunc(
+						checkDriftReportExcludesManaged(ref, appRef),
+					),
+				},
+			},
+		})
+}
+
+// checkDriftReportExcludesManaged - the app declared in 'managed_ids' must
+// not be reported as unmanaged
+func checkDriftReportExcludesManaged(resource string, appResource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) (err error) {
+
+		session := testAccProvider.Meta().(*cfapi.Session)
+
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("drift report '%s' not found in terraform state", resource)
+		}
+		app, ok := s.RootModule().Resources[appResource]
+		if !ok {
+			return fmt.Errorf("app '%s' not found in terraform state", appResource)
+		}
+
+		session.Log.DebugMessage(
+			"terraform state for resource '%s': %# v",
+			resource, rs)
+
+		for k, v := range rs.Primary.Attributes {
+			if strings.HasPrefix(k, "unmanaged_apps.") && strings.HasSuffix(k, ".id") && v == app.Primary.ID {
+				return fmt.Errorf("managed app '%s' was reported as unmanaged", app.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
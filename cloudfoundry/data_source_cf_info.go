@@ -2,6 +2,8 @@ package cloudfoundry
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
@@ -58,6 +60,27 @@ func dataSourceInfo() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"min_cli_version": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"min_recommended_cli_version": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"http_router_hostname": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"http_router_ips": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"tcp_router_hostname": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -81,6 +104,32 @@ func dataSourceInfoRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("routing_endpoint", info.RoutingAPIEndpoint)
 	d.Set("logging_endpoint", info.LoggregatorEndpoint)
 	d.Set("doppler_endpoint", info.DopplerEndpoint)
+	d.Set("min_cli_version", info.MinCLIVersion)
+	d.Set("min_recommended_cli_version", info.MinRecommendedCLIVersion)
+
+	// the API is served through the foundation's HTTP router / load balancer,
+	// so its hostname and addresses are the DNS target for app routes
+	if endpoint, err := url.Parse(info.APIEndpoint); err == nil && len(endpoint.Hostname()) > 0 {
+		d.Set("http_router_hostname", endpoint.Hostname())
+		if ips, err := net.LookupHost(endpoint.Hostname()); err == nil {
+			routerIPs := []interface{}{}
+			for _, ip := range ips {
+				routerIPs = append(routerIPs, ip)
+			}
+			d.Set("http_router_ips", routerIPs)
+		}
+	}
+
+	// TCP routes are reached through the shared domain bound to a tcp router
+	// group, conventionally tcp.<apps domain>
+	if domains, err := session.DomainManager().GetSharedDomains(); err == nil {
+		for _, domain := range domains {
+			if domain.RouterType == "tcp" {
+				d.Set("tcp_router_hostname", domain.Name)
+				break
+			}
+		}
+	}
 
 	d.SetId("info")
 	return nil
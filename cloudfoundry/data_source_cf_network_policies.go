@@ -0,0 +1,105 @@
+package cloudfoundry
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func dataSourceNetworkPolicies() *schema.Resource {
+
+	return &schema.Resource{
+
+		Read: dataSourceNetworkPoliciesRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"source_app": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"destination_app": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"policies": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_app": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"destination_app": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"protocol": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"port": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNetworkPoliciesRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	sourceApp := d.Get("source_app").(string)
+	destinationApp := d.Get("destination_app").(string)
+
+	var filter []string
+	if len(sourceApp) > 0 {
+		filter = append(filter, sourceApp)
+	}
+	if len(destinationApp) > 0 {
+		filter = append(filter, destinationApp)
+	}
+
+	all, err := session.NetworkPolicyManager().ListPolicies(filter...)
+	if err != nil {
+		return err
+	}
+
+	policies := []interface{}{}
+	for _, p := range all {
+		if len(sourceApp) > 0 && p.Source.ID != sourceApp {
+			continue
+		}
+		if len(destinationApp) > 0 && p.Destination.ID != destinationApp {
+			continue
+		}
+		policies = append(policies, map[string]interface{}{
+			"source_app":      p.Source.ID,
+			"destination_app": p.Destination.ID,
+			"protocol":        p.Destination.Protocol,
+			"port":            formatPolicyPort(p.Destination.Ports.Start, p.Destination.Ports.End),
+		})
+	}
+	d.Set("policies", policies)
+
+	switch {
+	case len(sourceApp) > 0:
+		d.SetId(sourceApp)
+	case len(destinationApp) > 0:
+		d.SetId(destinationApp)
+	default:
+		d.SetId("all")
+	}
+	return nil
+}
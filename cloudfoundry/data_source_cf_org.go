@@ -16,8 +16,15 @@ func dataSourceOrg() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"label_selector"},
+			},
+			"label_selector": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"name"},
 			},
 		},
 	}
@@ -37,7 +44,25 @@ func dataSourceOrgRead(d *schema.ResourceData, meta interface{}) (err error) {
 		org  cfapi.CCOrg
 	)
 
+	if selector, ok := d.GetOk("label_selector"); ok {
+		orgs, err := session.MetadataManager().ListByLabelSelector("organizations", selector.(string), nil)
+		if err != nil {
+			return err
+		}
+		if len(orgs) != 1 {
+			return fmt.Errorf("expected label selector '%s' to match exactly one org but it matched %d", selector.(string), len(orgs))
+		}
+		for id, orgName := range orgs {
+			d.SetId(id)
+			d.Set("name", orgName)
+		}
+		return nil
+	}
+
 	name = d.Get("name").(string)
+	if len(name) == 0 {
+		return fmt.Errorf("you must provide either the 'name' or the 'label_selector' attribute")
+	}
 
 	org, err = om.FindOrg(name)
 
@@ -0,0 +1,71 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func dataSourceOrgs() *schema.Resource {
+
+	return &schema.Resource{
+
+		Read: dataSourceOrgsRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"label_selector": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"orgs": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceOrgsRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	selector := d.Get("label_selector").(string)
+
+	matched, err := session.MetadataManager().ListByLabelSelector("organizations", selector, nil)
+	if err != nil {
+		return err
+	}
+
+	orgs := []interface{}{}
+	for id, name := range matched {
+		orgs = append(orgs, map[string]interface{}{
+			"id":   id,
+			"name": name,
+		})
+	}
+	sort.Slice(orgs, func(i, j int) bool {
+		return orgs[i].(map[string]interface{})["id"].(string) < orgs[j].(map[string]interface{})["id"].(string)
+	})
+	d.Set("orgs", orgs)
+	d.SetId(selector)
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package cloudfoundry
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func dataSourceQuotaUsage() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceQuotaUsageRead,
+		Schema: map[string]*schema.Schema{
+			"org": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"space"},
+			},
+			"space": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"org"},
+			},
+			"memory_in_use": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"routes_used": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"service_instances_used": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceQuotaUsageRead(d *schema.ResourceData, meta interface{}) (err error) {
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	var usage cfapi.CCQuotaUsage
+
+	qm := session.QuotaManager()
+	if org, ok := d.GetOk("org"); ok {
+		if usage, err = qm.GetOrgUsage(org.(string)); err != nil {
+			return err
+		}
+		d.SetId(org.(string))
+	} else if space, ok := d.GetOk("space"); ok {
+		if usage, err = qm.GetSpaceUsage(space.(string)); err != nil {
+			return err
+		}
+		d.SetId(space.(string))
+	} else {
+		return fmt.Errorf("one of 'org' or 'space' must be set")
+	}
+
+	d.Set("memory_in_use", int(usage.MemoryInUse))
+	d.Set("routes_used", usage.RoutesUsed)
+	d.Set("service_instances_used", usage.ServiceInstancesUsed)
+	return nil
+}
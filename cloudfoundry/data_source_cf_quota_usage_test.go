@@ -0,0 +1,74 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+const quotaUsageDataResource = `
+
+data "cloudfoundry_org" "org" {
+    name = "pcfdev-org"
+}
+
+data "cloudfoundry_quota_usage" "org" {
+	org = "${data.cloudfoundry_org.org.id}"
+}
+`
+
+func TestAccDataSourceQuotaUsage_normal(t *testing.T) {
+
+	ref := "data.cloudfoundry_quota_usage.org"
+
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+
+				resource.TestStep{
+					Config: quotaUsageDataResource,
+					Check: resource.ComposeTestCheckFunc(
+						checkDataSourceQuotaUsage(ref),
+					),
+				},
+			},
+		})
+}
+
+func checkDataSourceQuotaUsage(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) (err error) {
+
+		session := testAccProvider.Meta().(*cfapi.Session)
+
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("quota usage '%s' not found in terraform state", resource)
+		}
+
+		session.Log.DebugMessage(
+			"terraform state for resource '%s': %# v",
+			resource, rs)
+
+		id := rs.Primary.ID
+		attributes := rs.Primary.Attributes
+
+		usage, err := session.QuotaManager().GetOrgUsage(id)
+		if err != nil {
+			return err
+		}
+		if err = assertEquals(attributes, "memory_in_use", strconv.Itoa(int(usage.MemoryInUse))); err != nil {
+			return err
+		}
+		if err = assertEquals(attributes, "routes_used", strconv.Itoa(usage.RoutesUsed)); err != nil {
+			return err
+		}
+		return assertEquals(attributes, "service_instances_used", strconv.Itoa(usage.ServiceInstancesUsed))
+	}
+}
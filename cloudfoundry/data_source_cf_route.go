@@ -0,0 +1,93 @@
+package cloudfoundry
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func dataSourceRoute() *schema.Resource {
+
+	return &schema.Resource{
+
+		Read: dataSourceRouteRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"domain": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"hostname": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"path": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"port": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"space": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"apps": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      resourceStringHash,
+			},
+		},
+	}
+}
+
+func dataSourceRouteRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	rm := session.RouteManager()
+
+	var (
+		hostname, path *string
+		port           *int
+	)
+	if v, ok := d.GetOk("hostname"); ok {
+		vv := v.(string)
+		hostname = &vv
+	}
+	if v, ok := d.GetOk("path"); ok {
+		vv := v.(string)
+		path = &vv
+	}
+	if v, ok := d.GetOk("port"); ok {
+		vv := v.(int)
+		port = &vv
+	}
+
+	route, err := rm.FindRoute(d.Get("domain").(string), hostname, path, port)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(route.ID)
+	d.Set("space", route.SpaceGUID)
+
+	mappings, err := rm.ReadRouteMappingsByRoute(route.ID)
+	if err != nil {
+		return err
+	}
+	var apps []interface{}
+	for _, mapping := range mappings {
+		apps = append(apps, mapping["app"])
+	}
+	d.Set("apps", schema.NewSet(resourceStringHash, apps))
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package cloudfoundry
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestDataSourceRoute_fake(t *testing.T) {
+
+	f, session := newFakeSession(t)
+	defer f.Close()
+
+	f.Stub("GET", "/v2/routes", 200, map[string]interface{}{
+		"next_url": nil,
+		"resources": []interface{}{
+			map[string]interface{}{
+				"metadata": map[string]interface{}{"guid": "route-one"},
+				"entity": map[string]interface{}{
+					"host":        "www",
+					"domain_guid": "domain-one",
+					"space_guid":  "space-one",
+				},
+			},
+		},
+	})
+	f.Stub("GET", "/v2/shared_domains/domain-one", 200, map[string]interface{}{
+		"metadata": map[string]interface{}{"guid": "domain-one"},
+		"entity":   map[string]interface{}{"name": "apps.example.com"},
+	})
+	f.Stub("GET", "/v2/route_mappings", 200, map[string]interface{}{
+		"next_url": nil,
+		"resources": []interface{}{
+			map[string]interface{}{
+				"metadata": map[string]interface{}{"guid": "mapping-one"},
+				"entity":   map[string]interface{}{"app_guid": "app-one", "route_guid": "route-one"},
+			},
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceRoute().Schema, map[string]interface{}{
+		"domain":   "apps.example.com",
+		"hostname": "www",
+	})
+	if err := dataSourceRouteRead(d, session); err != nil {
+		t.Fatalf("read returned error: %s", err)
+	}
+	if d.Id() != "route-one" {
+		t.Errorf("expected the route GUID as ID, got '%s'", d.Id())
+	}
+	if v := d.Get("space").(string); v != "space-one" {
+		t.Errorf("expected the route's space, got '%s'", v)
+	}
+	apps := d.Get("apps").(*schema.Set)
+	if apps.Len() != 1 || !apps.Contains("app-one") {
+		t.Errorf("expected the mapped app 'app-one', got %v", apps.List())
+	}
+
+	// a route that does not exist is an error, not an empty result
+	d = schema.TestResourceDataRaw(t, dataSourceRoute().Schema, map[string]interface{}{
+		"domain":   "apps.example.com",
+		"hostname": "missing",
+	})
+	f.Stub("GET", "/v2/routes", 200, map[string]interface{}{
+		"next_url":  nil,
+		"resources": []interface{}{},
+	})
+	if err := dataSourceRouteRead(d, session); err == nil {
+		t.Error("expected an error for a route that does not exist")
+	}
+}
@@ -0,0 +1,82 @@
+package cloudfoundry
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func dataSourceRouteServiceBindings() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRouteServiceBindingsRead,
+		Schema: map[string]*schema.Schema{
+			"service_instance": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"route"},
+			},
+			"route": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"service_instance"},
+			},
+			"bindings": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"route": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"service_instance": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRouteServiceBindingsRead(d *schema.ResourceData, meta interface{}) (err error) {
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	bindings := []interface{}{}
+
+	if serviceInstance, ok := d.GetOk("service_instance"); ok {
+		routeIDs, err := session.ServiceManager().ReadRouteServiceBindings(serviceInstance.(string))
+		if err != nil {
+			return err
+		}
+		for _, routeID := range routeIDs {
+			bindings = append(bindings, map[string]interface{}{
+				"route":            routeID,
+				"service_instance": serviceInstance.(string),
+			})
+		}
+		d.SetId(serviceInstance.(string))
+	} else if routeID, ok := d.GetOk("route"); ok {
+		route, err := session.RouteManager().ReadRoute(routeID.(string))
+		if err != nil {
+			return err
+		}
+		if route.ServiceInstanceGUID != nil && len(*route.ServiceInstanceGUID) > 0 {
+			bindings = append(bindings, map[string]interface{}{
+				"route":            routeID.(string),
+				"service_instance": *route.ServiceInstanceGUID,
+			})
+		}
+		d.SetId(routeID.(string))
+	} else {
+		return fmt.Errorf("one of 'service_instance' or 'route' must be set")
+	}
+
+	d.Set("bindings", bindings)
+	return nil
+}
@@ -0,0 +1,141 @@
+package cloudfoundry
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func dataSourceSecurityGroupRules() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSecurityGroupRulesRead,
+		Schema: map[string]*schema.Schema{
+			"space": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"scope": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "running",
+				ValidateFunc: validation.StringInSlice([]string{"running", "staging"}, false),
+			},
+			"rules": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"security_group": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"protocol": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"destination": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ports": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"code": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"log": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceSecurityGroupRulesRead - compiles the effective set of egress
+// rules for a space by combining its bound security groups with the platform
+// wide running or staging defaults
+func dataSourceSecurityGroupRulesRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	space := d.Get("space").(string)
+	scope := d.Get("scope").(string)
+
+	sm := session.SpaceManager()
+	am := session.ASGManager()
+
+	var spaceAsgs []interface{}
+	var defaultAsgs []string
+	if scope == "staging" {
+		if spaceAsgs, err = sm.ListStagingASGs(space); err != nil {
+			return err
+		}
+		if defaultAsgs, err = am.Staging(); err != nil {
+			return err
+		}
+	} else {
+		if spaceAsgs, err = sm.ListASGs(space); err != nil {
+			return err
+		}
+		if defaultAsgs, err = am.Running(); err != nil {
+			return err
+		}
+	}
+
+	asgIDs := []string{}
+	seen := make(map[string]bool)
+	for _, a := range spaceAsgs {
+		if !seen[a.(string)] {
+			seen[a.(string)] = true
+			asgIDs = append(asgIDs, a.(string))
+		}
+	}
+	for _, a := range defaultAsgs {
+		if !seen[a] {
+			seen[a] = true
+			asgIDs = append(asgIDs, a)
+		}
+	}
+
+	rules := []interface{}{}
+	for _, id := range asgIDs {
+		asg, err := am.GetASG(id)
+		if err != nil {
+			return err
+		}
+		for _, r := range asg.Rules {
+			rules = append(rules, map[string]interface{}{
+				"security_group": asg.Name,
+				"protocol":       r.Protocol,
+				"destination":    r.Destination,
+				"ports":          r.Ports,
+				"type":           r.Type,
+				"code":           r.Code,
+				"log":            r.Log,
+				"description":    r.Description,
+			})
+		}
+	}
+
+	d.Set("rules", rules)
+	d.SetId(space + "/" + scope)
+	return nil
+}
@@ -0,0 +1,90 @@
+package cloudfoundry
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestDataSourceSecurityGroupRules_fake(t *testing.T) {
+
+	f, session := newFakeSession(t)
+	defer f.Close()
+
+	f.Stub("GET", "/v2/spaces/space-one/security_groups", 200, map[string]interface{}{
+		"next_url": nil,
+		"resources": []interface{}{
+			map[string]interface{}{
+				"metadata": map[string]interface{}{"guid": "asg-space"},
+				"entity":   map[string]interface{}{"name": "space-asg"},
+			},
+		},
+	})
+	// the space bound group is also a platform default; it must only be
+	// reported once
+	f.Stub("GET", "/v2/config/running_security_groups", 200, map[string]interface{}{
+		"next_url": nil,
+		"resources": []interface{}{
+			map[string]interface{}{
+				"metadata": map[string]interface{}{"guid": "asg-space"},
+				"entity":   map[string]interface{}{"name": "space-asg"},
+			},
+			map[string]interface{}{
+				"metadata": map[string]interface{}{"guid": "asg-default"},
+				"entity":   map[string]interface{}{"name": "platform-default"},
+			},
+		},
+	})
+	f.Stub("GET", "/v2/security_groups/asg-space", 200, map[string]interface{}{
+		"metadata": map[string]interface{}{"guid": "asg-space"},
+		"entity": map[string]interface{}{
+			"name": "space-asg",
+			"rules": []interface{}{
+				map[string]interface{}{
+					"protocol":    "tcp",
+					"destination": "10.0.0.0/24",
+					"ports":       "443",
+					"description": "internal services",
+				},
+			},
+		},
+	})
+	f.Stub("GET", "/v2/security_groups/asg-default", 200, map[string]interface{}{
+		"metadata": map[string]interface{}{"guid": "asg-default"},
+		"entity": map[string]interface{}{
+			"name": "platform-default",
+			"rules": []interface{}{
+				map[string]interface{}{
+					"protocol":    "all",
+					"destination": "0.0.0.0/0",
+				},
+			},
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceSecurityGroupRules().Schema, map[string]interface{}{
+		"space": "space-one",
+	})
+	if err := dataSourceSecurityGroupRulesRead(d, session); err != nil {
+		t.Fatalf("read returned error: %s", err)
+	}
+	if d.Id() != "space-one/running" {
+		t.Errorf("unexpected ID '%s'", d.Id())
+	}
+
+	rules := d.Get("rules").([]interface{})
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules with the shared group de-duplicated, got %d", len(rules))
+	}
+	first := rules[0].(map[string]interface{})
+	if v := first["security_group"]; v != "space-asg" {
+		t.Errorf("expected the space bound group's rules first, got '%v'", v)
+	}
+	if v := first["destination"]; v != "10.0.0.0/24" {
+		t.Errorf("unexpected destination '%v'", v)
+	}
+	second := rules[1].(map[string]interface{})
+	if v := second["security_group"]; v != "platform-default" {
+		t.Errorf("expected the platform default group's rules, got '%v'", v)
+	}
+}
@@ -0,0 +1,125 @@
+package cloudfoundry
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func dataSourceServiceOfferings() *schema.Resource {
+
+	return &schema.Resource{
+
+		Read: dataSourceServiceOfferingsRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"space": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"services": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"label": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"service_broker_guid": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"plans": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"name": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"description": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"free": &schema.Schema{
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									"public": &schema.Schema{
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceServiceOfferingsRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	sm := session.ServiceManager()
+
+	space := d.Get("space").(string)
+
+	offerings, err := sm.ListServiceOfferings(space)
+	if err != nil {
+		return err
+	}
+
+	services := []interface{}{}
+	for _, o := range offerings {
+
+		plans := []interface{}{}
+		for _, p := range o.ServicePlans {
+			plans = append(plans, map[string]interface{}{
+				"id":          p.ID,
+				"name":        p.Name,
+				"description": p.Description,
+				"free":        p.Free,
+				"public":      p.Public,
+			})
+		}
+
+		services = append(services, map[string]interface{}{
+			"id":                  o.ID,
+			"label":               o.Label,
+			"description":         o.Description,
+			"service_broker_guid": o.ServiceBrokerGUID,
+			"plans":               plans,
+		})
+	}
+	d.Set("services", services)
+
+	if len(space) > 0 {
+		d.SetId(space)
+	} else {
+		d.SetId("marketplace")
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+const serviceOfferingsDataResource = `
+
+data "cloudfoundry_service_offerings" "marketplace" {
+}
+`
+
+func TestAccDataSourceServiceOfferings_normal(t *testing.T) {
+
+	ref := "data.cloudfoundry_service_offerings.marketplace"
+
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+
+				resource.TestStep{
+					Config: serviceOfferingsDataResource,
+					Check: resource.ComposeTestCheckFunc(
+						checkDataSourceServiceOfferingsNotEmpty(ref),
+					),
+				},
+			},
+		})
+}
+
+func checkDataSourceServiceOfferingsNotEmpty(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("service offerings '%s' not found in terraform state", resource)
+		}
+
+		count, err := strconv.Atoi(rs.Primary.Attributes["services.#"])
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return fmt.Errorf("expected at least one service offering in the marketplace")
+		}
+		return nil
+	}
+}
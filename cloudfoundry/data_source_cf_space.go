@@ -16,8 +16,15 @@ func dataSourceSpace() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"label_selector"},
+			},
+			"label_selector": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"name"},
 			},
 			"org_name": &schema.Schema{
 				Type:          schema.TypeString,
@@ -72,12 +79,31 @@ func dataSourceSpaceRead(d *schema.ResourceData, meta interface{}) (err error) {
 	} else {
 		return fmt.Errorf("You must provide either 'org' or 'org_name' attribute")
 	}
+
+	if selector, hasSelector := d.GetOk("label_selector"); hasSelector {
+		spaces, err := session.MetadataManager().ListByLabelSelector(
+			"spaces", selector.(string), map[string]string{"organization_guids": org.ID})
+		if err != nil {
+			return err
+		}
+		if len(spaces) != 1 {
+			return fmt.Errorf("expected label selector '%s' to match exactly one space in org '%s' but it matched %d",
+				selector.(string), org.Name, len(spaces))
+		}
+		for id := range spaces {
+			name = spaces[id]
+		}
+	} else if len(name) == 0 {
+		return fmt.Errorf("You must provide either the 'name' or the 'label_selector' attribute")
+	}
+
 	space, err = sm.FindSpaceInOrg(name, org.ID)
 	if err != nil {
 		return err
 	}
 
 	d.SetId(space.ID)
+	d.Set("name", name)
 	d.Set("org_name", org.Name)
 	d.Set("org", org.ID)
 	d.Set("quota", space.QuotaGUID)
@@ -0,0 +1,87 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func dataSourceSpaces() *schema.Resource {
+
+	return &schema.Resource{
+
+		Read: dataSourceSpacesRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"label_selector": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"org": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"spaces": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSpacesRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	selector := d.Get("label_selector").(string)
+	org := d.Get("org").(string)
+
+	var filters map[string]string
+	if len(org) > 0 {
+		filters = map[string]string{"organization_guids": org}
+	}
+
+	matched, err := session.MetadataManager().ListByLabelSelector("spaces", selector, filters)
+	if err != nil {
+		return err
+	}
+
+	spaces := []interface{}{}
+	for id, name := range matched {
+		spaces = append(spaces, map[string]interface{}{
+			"id":   id,
+			"name": name,
+		})
+	}
+	sort.Slice(spaces, func(i, j int) bool {
+		return spaces[i].(map[string]interface{})["id"].(string) < spaces[j].(map[string]interface{})["id"].(string)
+	})
+	d.Set("spaces", spaces)
+
+	if len(org) > 0 {
+		d.SetId(org + "/" + selector)
+	} else {
+		d.SetId(selector)
+	}
+
+	return nil
+}
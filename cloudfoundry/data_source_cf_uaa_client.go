@@ -0,0 +1,70 @@
+package cloudfoundry
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func dataSourceUaaClient() *schema.Resource {
+
+	return &schema.Resource{
+
+		Read: dataSourceUaaClientRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"client_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"scopes": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      resourceStringHash,
+			},
+			"authorities": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      resourceStringHash,
+			},
+			"authorized_grant_types": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      resourceStringHash,
+			},
+		},
+	}
+}
+
+func dataSourceUaaClientRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	client, err := session.UserManager().GetClient(d.Get("client_id").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(client.ClientID)
+	d.Set("scopes", schema.NewSet(resourceStringHash, stringListToInterfaceList(client.Scope)))
+	d.Set("authorities", schema.NewSet(resourceStringHash, stringListToInterfaceList(client.Authorities)))
+	d.Set("authorized_grant_types", schema.NewSet(resourceStringHash, stringListToInterfaceList(client.AuthorizedGrantTypes)))
+
+	return nil
+}
+
+// stringListToInterfaceList -
+func stringListToInterfaceList(values []string) (list []interface{}) {
+	for _, v := range values {
+		list = append(list, v)
+	}
+	return list
+}
@@ -0,0 +1,70 @@
+package cloudfoundry
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func dataSourceUaaUser() *schema.Resource {
+
+	return &schema.Resource{
+
+		Read: dataSourceUaaUserRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"origin": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"email": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"groups": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      resourceStringHash,
+			},
+		},
+	}
+}
+
+func dataSourceUaaUserRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	um := session.UserManager()
+
+	user, err := um.FindUAAUserByName(d.Get("name").(string), d.Get("origin").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(user.ID)
+	d.Set("origin", user.Origin)
+	if len(user.Emails) > 0 {
+		d.Set("email", user.Emails[0].Value)
+	}
+
+	var groups []interface{}
+	for _, g := range user.Groups {
+		if !um.IsDefaultGroup(g.Display) {
+			groups = append(groups, g.Display)
+		}
+	}
+	d.Set("groups", schema.NewSet(resourceStringHash, groups))
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package cloudfoundry
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestDataSourceUaaUser_fake(t *testing.T) {
+
+	f, session := newFakeSession(t)
+	defer f.Close()
+
+	f.Stub("GET", "/Users", 200, map[string]interface{}{
+		"resources": []interface{}{
+			map[string]interface{}{
+				"id":       "user-one",
+				"userName": "jdoe",
+				"origin":   "uaa",
+				"emails":   []interface{}{map[string]interface{}{"value": "jdoe@example.com"}},
+				"groups": []interface{}{
+					map[string]interface{}{"display": "cloud_controller.admin"},
+				},
+			},
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceUaaUser().Schema, map[string]interface{}{
+		"name": "jdoe",
+	})
+	if err := dataSourceUaaUserRead(d, session); err != nil {
+		t.Fatalf("read returned error: %s", err)
+	}
+	if d.Id() != "user-one" {
+		t.Errorf("expected the user ID, got '%s'", d.Id())
+	}
+	if v := d.Get("email").(string); v != "jdoe@example.com" {
+		t.Errorf("expected the user's primary email, got '%s'", v)
+	}
+	if groups := d.Get("groups").(*schema.Set); !groups.Contains("cloud_controller.admin") {
+		t.Errorf("expected the user's groups, got %v", groups.List())
+	}
+
+	// an unknown username is an error, not an empty result
+	f.Stub("GET", "/Users", 200, map[string]interface{}{
+		"resources": []interface{}{},
+	})
+	d = schema.TestResourceDataRaw(t, dataSourceUaaUser().Schema, map[string]interface{}{
+		"name": "missing",
+	})
+	if err := dataSourceUaaUserRead(d, session); err == nil {
+		t.Error("expected an error for an unknown user")
+	}
+}
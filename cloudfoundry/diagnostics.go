@@ -0,0 +1,86 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// wrapDiagnostics - decorates the CRUD entry points of every resource and
+// data source so raw Cloud Controller errors are classified and surfaced
+// with a remediation hint instead of an opaque JSON payload
+func wrapDiagnostics(resources map[string]*schema.Resource) map[string]*schema.Resource {
+
+	for name, resource := range resources {
+		resource.Create = wrapDiagnosticsFunc(name, resource.Create)
+		resource.Read = wrapDiagnosticsFunc(name, resource.Read)
+		resource.Update = wrapDiagnosticsFunc(name, resource.Update)
+		resource.Delete = wrapDiagnosticsFunc(name, resource.Delete)
+	}
+	return resources
+}
+
+// wrapDiagnosticsFunc -
+func wrapDiagnosticsFunc(name string, f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+
+	if f == nil {
+		return nil
+	}
+	return func(d *schema.ResourceData, meta interface{}) error {
+		return diagnoseError(name, f(d, meta))
+	}
+}
+
+// diagnoseError - classifies the given error and appends a remediation hint
+// for the failure classes users most commonly run into; errors that are not
+// recognised are passed through unchanged
+func diagnoseError(name string, err error) error {
+
+	if err == nil {
+		return nil
+	}
+	message := strings.ToLower(err.Error())
+
+	var hint string
+	switch {
+
+	case strings.Contains(message, "client is nil"):
+		return fmt.Errorf("%s: the Cloud Foundry session was not initialized; "+
+			"verify the provider configuration ('api_url', credentials and 'ca_cert')", name)
+
+	case strings.Contains(message, "cf-notauthenticated") ||
+		strings.Contains(message, "cf-invalidauthtoken") ||
+		strings.Contains(message, "invalid_token") ||
+		strings.Contains(message, "status code: 401"):
+		hint = "authentication with the Cloud Controller failed; " +
+			"check the provider's 'user'/'password' or 'uaa_client_id'/'uaa_client_secret' and that the token has not expired"
+
+	case strings.Contains(message, "cf-notauthorized") ||
+		strings.Contains(message, "status code: 403"):
+		hint = "the authenticated user is not permitted to perform this operation; " +
+			"grant the required org or space role, or use credentials with administrator scope"
+
+	case strings.Contains(message, "cf-quota") ||
+		(strings.Contains(message, "quota") && strings.Contains(message, "exceed")):
+		hint = "an org or space quota is exhausted; " +
+			"raise the quota ('cloudfoundry_org_quota'/'cloudfoundry_space_quota'), or reduce the requested memory, instances or service instances"
+
+	case strings.Contains(message, "cf-serviceplannotpublic") ||
+		(strings.Contains(message, "plan") && strings.Contains(message, "not") && strings.Contains(message, "public")):
+		hint = "the requested service plan is not visible to the org; " +
+			"make the plan public or grant access with 'cloudfoundry_service_plan_access'"
+
+	case strings.Contains(message, "cf-routehosttaken") ||
+		strings.Contains(message, "cf-routeporttaken") ||
+		strings.Contains(message, "cf-routepathtaken") ||
+		(strings.Contains(message, "route") && strings.Contains(message, "taken")):
+		hint = "the route is already owned by another org or space; " +
+			"pick a different hostname, or import or remove the conflicting route"
+	}
+
+	if len(hint) == 0 {
+		return err
+	}
+	return fmt.Errorf("%s: %s\n\nSuggested remediation: %s", name, err.Error(), hint)
+}
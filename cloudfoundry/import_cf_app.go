@@ -2,6 +2,7 @@ package cloudfoundry
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
@@ -15,6 +16,16 @@ func resourceAppImport(d *schema.ResourceData, meta interface{}) ([]*schema.Reso
 		return []*schema.ResourceData{}, fmt.Errorf("client is nil")
 	}
 	am := session.AppManager()
+
+	if idParts := strings.Split(d.Id(), "/"); len(idParts) == 2 {
+		// import by "<space guid>/<app name>"
+		app, err := am.FindAppInSpace(idParts[1], idParts[0])
+		if err != nil {
+			return []*schema.ResourceData{}, err
+		}
+		d.SetId(app.ID)
+	}
+
 	mappings, err := am.ReadServiceBindingsByApp(d.Id())
 	if err != nil {
 		return []*schema.ResourceData{}, err
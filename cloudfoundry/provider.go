@@ -1,6 +1,9 @@
 package cloudfoundry
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -45,47 +48,136 @@ func Provider() terraform.ResourceProvider {
 				Required:    true,
 				DefaultFunc: schema.EnvDefaultFunc("CF_SKIP_SSL_VALIDATION", "true"),
 			},
+			"enforce_managed_by": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CF_ENFORCE_MANAGED_BY", "false"),
+			},
+			"default_app_timeout": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CF_DEFAULT_APP_TIMEOUT", 0),
+			},
+			"default_staging_timeout": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CF_DEFAULT_STAGING_TIMEOUT", 0),
+			},
+			"default_service_timeout": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CF_DEFAULT_SERVICE_TIMEOUT", 0),
+			},
+			"app_artifact_cache": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CF_APP_ARTIFACT_CACHE", ""),
+			},
+			"name_prefix": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CF_NAME_PREFIX", ""),
+			},
+			"name_suffix": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CF_NAME_SUFFIX", ""),
+			},
+			"work_dir": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CF_WORK_DIR", ""),
+			},
+			"max_upload_size_mb": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CF_MAX_UPLOAD_SIZE_MB", 0),
+			},
+			"approved_route_domains": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"service_broker_concurrency": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"simulate": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CF_SIMULATE", "false"),
+			},
 		},
 
-		DataSourcesMap: map[string]*schema.Resource{
-			"cloudfoundry_info":              dataSourceInfo(),
-			"cloudfoundry_stack":             dataSourceStack(),
-			"cloudfoundry_router_group":      dataSourceRouterGroup(),
-			"cloudfoundry_user":              dataSourceUser(),
-			"cloudfoundry_domain":            dataSourceDomain(),
-			"cloudfoundry_asg":               dataSourceAsg(),
-			"cloudfoundry_org":               dataSourceOrg(),
-			"cloudfoundry_org_quota":         dataSourceOrgQuota(),
-			"cloudfoundry_space_quota":       dataSourceSpaceQuota(),
-			"cloudfoundry_space":             dataSourceSpace(),
-			"cloudfoundry_service":           dataSourceService(),
-			"cloudfoundry_isolation_segment": dataSourceSegment(),
-		},
+		DataSourcesMap: wrapDiagnostics(map[string]*schema.Resource{
+			"cloudfoundry_info":                   dataSourceInfo(),
+			"cloudfoundry_stack":                  dataSourceStack(),
+			"cloudfoundry_router_group":           dataSourceRouterGroup(),
+			"cloudfoundry_user":                   dataSourceUser(),
+			"cloudfoundry_uaa_user":               dataSourceUaaUser(),
+			"cloudfoundry_uaa_client":             dataSourceUaaClient(),
+			"cloudfoundry_domain":                 dataSourceDomain(),
+			"cloudfoundry_asg":                    dataSourceAsg(),
+			"cloudfoundry_org":                    dataSourceOrg(),
+			"cloudfoundry_orgs":                   dataSourceOrgs(),
+			"cloudfoundry_org_quota":              dataSourceOrgQuota(),
+			"cloudfoundry_space_quota":            dataSourceSpaceQuota(),
+			"cloudfoundry_quota_usage":            dataSourceQuotaUsage(),
+			"cloudfoundry_space":                  dataSourceSpace(),
+			"cloudfoundry_spaces":                 dataSourceSpaces(),
+			"cloudfoundry_service":                dataSourceService(),
+			"cloudfoundry_service_offerings":      dataSourceServiceOfferings(),
+			"cloudfoundry_isolation_segment":      dataSourceSegment(),
+			"cloudfoundry_network_policies":       dataSourceNetworkPolicies(),
+			"cloudfoundry_buildpacks":             dataSourceBuildpacks(),
+			"cloudfoundry_app_routes":             dataSourceAppRoutes(),
+			"cloudfoundry_app_port_forwarding":    dataSourceAppPortForwarding(),
+			"cloudfoundry_drift_report":           dataSourceDriftReport(),
+			"cloudfoundry_route":                  dataSourceRoute(),
+			"cloudfoundry_route_service_bindings": dataSourceRouteServiceBindings(),
+			"cloudfoundry_security_group_rules":   dataSourceSecurityGroupRules(),
+		}),
 
-		ResourcesMap: map[string]*schema.Resource{
-			"cloudfoundry_feature_flags":                 resourceConfig(),
-			"cloudfoundry_user":                          resourceUser(),
-			"cloudfoundry_domain":                        resourceDomain(),
-			"cloudfoundry_private_domain_access":         resourcePrivateDomainAccess(),
-			"cloudfoundry_asg":                           resourceAsg(),
-			"cloudfoundry_org_quota":                     resourceOrgQuota(),
-			"cloudfoundry_space_quota":                   resourceSpaceQuota(),
-			"cloudfoundry_default_asg":                   resourceDefaultAsg(),
-			"cloudfoundry_evg":                           resourceEvg(),
-			"cloudfoundry_org":                           resourceOrg(),
-			"cloudfoundry_space":                         resourceSpace(),
-			"cloudfoundry_service_broker":                resourceServiceBroker(),
-			"cloudfoundry_service_plan_access":           resourceServicePlanAccess(),
-			"cloudfoundry_service_instance":              resourceServiceInstance(),
-			"cloudfoundry_service_key":                   resourceServiceKey(),
-			"cloudfoundry_user_provided_service":         resourceUserProvidedService(),
-			"cloudfoundry_buildpack":                     resourceBuildpack(),
-			"cloudfoundry_route":                         resourceRoute(),
-			"cloudfoundry_route_service_binding":         resourceRouteServiceBinding(),
-			"cloudfoundry_app":                           resourceApp(),
-			"cloudfoundry_isolation_segment":             resourceSegment(),
-			"cloudfoundry_isolation_segment_entitlement": resourceSegmentEntitlement(),
-		},
+		ResourcesMap: wrapDiagnostics(map[string]*schema.Resource{
+			"cloudfoundry_feature_flags":                        resourceConfig(),
+			"cloudfoundry_user":                                 resourceUser(),
+			"cloudfoundry_user_invitation":                      resourceUserInvitation(),
+			"cloudfoundry_uaa_identity_provider":                resourceUaaIdentityProvider(),
+			"cloudfoundry_domain":                               resourceDomain(),
+			"cloudfoundry_private_domain_access":                resourcePrivateDomainAccess(),
+			"cloudfoundry_asg":                                  resourceAsg(),
+			"cloudfoundry_org_quota":                            resourceOrgQuota(),
+			"cloudfoundry_space_quota":                          resourceSpaceQuota(),
+			"cloudfoundry_quota_assignment":                     resourceQuotaAssignment(),
+			"cloudfoundry_default_asg":                          resourceDefaultAsg(),
+			"cloudfoundry_evg":                                  resourceEvg(),
+			"cloudfoundry_evg_entry":                            resourceEvgEntry(),
+			"cloudfoundry_org":                                  resourceOrg(),
+			"cloudfoundry_org_suspension":                       resourceOrgSuspension(),
+			"cloudfoundry_space":                                resourceSpace(),
+			"cloudfoundry_space_feature":                        resourceSpaceFeature(),
+			"cloudfoundry_space_role_bundle":                    resourceSpaceRoleBundle(),
+			"cloudfoundry_service_broker":                       resourceServiceBroker(),
+			"cloudfoundry_service_plan_access":                  resourceServicePlanAccess(),
+			"cloudfoundry_service_instance":                     resourceServiceInstance(),
+			"cloudfoundry_service_key":                          resourceServiceKey(),
+			"cloudfoundry_service_instance_credential_rotation": resourceServiceInstanceCredentialRotation(),
+			"cloudfoundry_service_instance_snapshot":            resourceServiceInstanceSnapshot(),
+			"cloudfoundry_user_provided_service":                resourceUserProvidedService(),
+			"cloudfoundry_app_log_drain":                        resourceAppLogDrain(),
+			"cloudfoundry_buildpack":                            resourceBuildpack(),
+			"cloudfoundry_route":                                resourceRoute(),
+			"cloudfoundry_internal_route":                       resourceInternalRoute(),
+			"cloudfoundry_app_cname":                            resourceAppCname(),
+			"cloudfoundry_route_service_binding":                resourceRouteServiceBinding(),
+			"cloudfoundry_app":                                  resourceApp(),
+			"cloudfoundry_droplet_copy":                         resourceDropletCopy(),
+			"cloudfoundry_app_copy_bits":                        resourceAppCopyBits(),
+			"cloudfoundry_network_policy_set":                   resourceNetworkPolicySet(),
+			"cloudfoundry_isolation_segment":                    resourceSegment(),
+			"cloudfoundry_isolation_segment_entitlement":        resourceSegmentEntitlement(),
+		}),
 
 		ConfigureFunc: providerConfigure,
 	}
@@ -101,6 +193,31 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		UaaClientSecret:   d.Get("uaa_client_secret").(string),
 		CACert:            d.Get("ca_cert").(string),
 		SkipSslValidation: d.Get("skip_ssl_validation").(bool),
+		EnforceManagedBy:  d.Get("enforce_managed_by").(bool),
+
+		DefaultAppTimeout:     d.Get("default_app_timeout").(int),
+		DefaultStagingTimeout: d.Get("default_staging_timeout").(int),
+		DefaultServiceTimeout: d.Get("default_service_timeout").(int),
+
+		AppArtifactCache: d.Get("app_artifact_cache").(string),
+		WorkDir:          d.Get("work_dir").(string),
+		MaxUploadSizeMB:  d.Get("max_upload_size_mb").(int),
+		NamePrefix:       d.Get("name_prefix").(string),
+		NameSuffix:       d.Get("name_suffix").(string),
+		Simulate:         d.Get("simulate").(bool),
+	}
+
+	for _, v := range d.Get("approved_route_domains").([]interface{}) {
+		config.ApprovedRouteDomains = append(config.ApprovedRouteDomains, v.(string))
+	}
+
+	config.BrokerConcurrency = make(map[string]int)
+	for broker, v := range d.Get("service_broker_concurrency").(map[string]interface{}) {
+		limit, err := strconv.Atoi(v.(string))
+		if err != nil || limit < 1 {
+			return nil, fmt.Errorf("service_broker_concurrency for broker '%s' must be a positive integer, got '%v'", broker, v)
+		}
+		config.BrokerConcurrency[broker] = limit
 	}
 	return config.Client()
 }
@@ -0,0 +1,52 @@
+package cloudfoundry
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+const simulatedResource = `
+
+provider "cloudfoundry" {
+	api_url             = "https://api.simulated.example.com"
+	user                = "admin"
+	password            = "admin"
+	ca_cert             = ""
+	skip_ssl_validation = true
+	simulate            = true
+}
+
+resource "cloudfoundry_org" "org" {
+	name = "simulated-org"
+}
+
+resource "cloudfoundry_space" "space" {
+	name = "simulated-space"
+	org  = "${cloudfoundry_org.org.id}"
+}
+`
+
+// TestProvider_simulatedPlan - validates a representative configuration can
+// be planned with 'simulate = true', backed by the in-process fake Cloud
+// Controller instead of a real foundation
+func TestProvider_simulatedPlan(t *testing.T) {
+
+	simulatedProvider := Provider().(*schema.Provider)
+
+	resource.UnitTest(t,
+		resource.TestCase{
+			Providers: map[string]terraform.ResourceProvider{
+				"cloudfoundry": simulatedProvider,
+			},
+			Steps: []resource.TestStep{
+				resource.TestStep{
+					Config:             simulatedResource,
+					PlanOnly:           true,
+					ExpectNonEmptyPlan: true,
+				},
+			},
+		})
+}
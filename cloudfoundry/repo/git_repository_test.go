@@ -108,7 +108,7 @@ func testTaggedContent(t *testing.T) {
 func getGitRepo(t *testing.T) (gitRepo repo.Repository) {
 
 	repoManager := repo.NewRepoManager()
-	gitRepo, err := repoManager.GetGitRepository("", "https://github.com/mevansam/test-app.git", nil, nil, nil)
+	gitRepo, err := repoManager.GetGitRepository("", "https://github.com/mevansam/test-app.git", nil, nil, nil, nil, repo.GitProviderGitHub)
 	checkError(t, err)
 
 	path := gitRepo.GetPath()
@@ -10,6 +10,7 @@ import (
 	"gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/plumbing"
 	"gopkg.in/src-d/go-git.v4/plumbing/transport"
+	githttp "gopkg.in/src-d/go-git.v4/plumbing/transport/http"
 	"gopkg.in/src-d/go-git.v4/plumbing/transport/ssh"
 	"io/ioutil"
 )
@@ -22,6 +23,15 @@ const (
 	DefaultVersionType = 0
 )
 
+const (
+	// GitProviderGitHub -
+	GitProviderGitHub = "github"
+	// GitProviderGitLab -
+	GitProviderGitLab = "gitlab"
+	// GitProviderGeneric -
+	GitProviderGeneric = "generic"
+)
+
 // Repository -
 type Repository interface {
 	GetPath() string
@@ -42,7 +52,7 @@ func NewRepoManager() *RepoManager {
 }
 
 // GetGitRepository -
-func (rm *RepoManager) GetGitRepository(name string, repoURL string, user, password, privateKey *string) (repo Repository, err error) {
+func (rm *RepoManager) GetGitRepository(name string, repoURL string, user, password, privateKey, token *string, gitProvider string) (repo Repository, err error) {
 
 	rm.gitMutex.Lock()
 	defer rm.gitMutex.Unlock()
@@ -56,9 +66,22 @@ func (rm *RepoManager) GetGitRepository(name string, repoURL string, user, passw
 
 	p = p + "/" + name
 
-	if user != nil {
+	var auth transport.AuthMethod
+
+	if token != nil {
 
-		var auth transport.AuthMethod
+		// token authentication is header based; the provider hint picks the
+		// username convention the git server expects for its access tokens
+		switch gitProvider {
+		case GitProviderGitLab:
+			auth = githttp.NewBasicAuth("oauth2", *token)
+		case GitProviderGeneric:
+			auth = githttp.NewBasicAuth("git", *token)
+		default:
+			auth = githttp.NewBasicAuth("x-access-token", *token)
+		}
+
+	} else if user != nil {
 
 		if password != nil {
 
@@ -78,6 +101,9 @@ func (rm *RepoManager) GetGitRepository(name string, repoURL string, user, passw
 		if err != nil {
 			return
 		}
+	}
+
+	if auth != nil {
 		r, err = git.PlainClone(p, false,
 			&git.CloneOptions{
 				URL:               repoURL,
@@ -1,6 +1,8 @@
 package cloudfoundry
 
 import (
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -36,6 +38,8 @@ func resourceApp() *schema.Resource {
 			State: resourceAppImport,
 		},
 
+		CustomizeDiff: resourceAppCustomizeDiff,
+
 		SchemaVersion: 4,
 		Schema: map[string]*schema.Schema{
 
@@ -43,6 +47,11 @@ func resourceApp() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"apply_name_affixes": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"space": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
@@ -72,7 +81,6 @@ func resourceApp() *schema.Resource {
 			"stack": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				Computed: true,
 			},
 			"buildpack": &schema.Schema{
@@ -90,16 +98,135 @@ func resourceApp() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"validate_ssh": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "Fail the plan if 'enable_ssh' conflicts with the space or platform SSH settings.",
+				Optional:    true,
+				Default:     false,
+			},
 			"timeout": &schema.Schema{
 				Type:     schema.TypeInt,
 				Optional: true,
-				Default:  DefaultAppTimeout,
+				Computed: true,
+			},
+			"work_dir": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
 			},
 			"stopped": &schema.Schema{
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  false,
 			},
+			"stage_only": &schema.Schema{
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"docker_image"},
+			},
+			"droplet_guid": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"wait_for_start": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"running_instances": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"crashed_instances": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"last_staged_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"prevent_destroy_in_cf": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"maintenance_window": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateHHMMTime,
+						},
+						"end": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateHHMMTime,
+						},
+						"days": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{
+									"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday",
+								}, false),
+							},
+						},
+						"on_violation": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "fail",
+							ValidateFunc: validation.StringInSlice([]string{"fail", "wait"}, false),
+						},
+					},
+				},
+			},
+			"post_start_probe": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"expected_status": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  200,
+						},
+						"retries": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  10,
+						},
+						"interval": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  5,
+						},
+					},
+				},
+			},
+			"droplet_inspection": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"docker_image", "docker_credentials"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"command": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
 			"url": &schema.Schema{
 				Type:          schema.TypeString,
 				Optional:      true,
@@ -150,6 +277,18 @@ func resourceApp() *schema.Resource {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
+						"token": &schema.Schema{
+							Type:          schema.TypeString,
+							Optional:      true,
+							Sensitive:     true,
+							ConflictsWith: []string{"git.password", "git.key"},
+						},
+						"provider": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "github",
+							ValidateFunc: validation.StringInSlice([]string{"github", "gitlab", "generic"}, false),
+						},
 					},
 				},
 			},
@@ -217,13 +356,39 @@ func resourceApp() *schema.Resource {
 							Type:     schema.TypeMap,
 							Optional: true,
 						},
+						// any change recreates the binding so the broker
+						// issues fresh credentials; the restage that follows
+						// binding changes refreshes VCAP_SERVICES
+						"rotation_keeper": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
 						"binding_id": &schema.Schema{
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"credentials": &schema.Schema{
+							Type:      schema.TypeMap,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"credentials_hash": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
 					},
 				},
 			},
+			"store_credentials_in_state": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"wait_for_service_instances": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"route": &schema.Schema{
 				Type:          schema.TypeList,
 				Optional:      true,
@@ -283,7 +448,6 @@ func resourceApp() *schema.Resource {
 							Type:         schema.TypeInt,
 							Optional:     true,
 							Computed:     true,
-							Deprecated:   "Not yet implemented!",
 							ValidateFunc: validation.IntBetween(1, 65535),
 						},
 						"mapping_id": &schema.Schema{
@@ -306,6 +470,23 @@ func resourceApp() *schema.Resource {
 				Computed:  true,
 				Sensitive: true,
 			},
+			"environment_file": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"environment_file_hash": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_operation_summary": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			"log_rate_limit_in_bytes_per_second": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  -1,
+			},
 			"health_check_http_endpoint": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -322,6 +503,46 @@ func resourceApp() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"web_process_guid": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"current_droplet_guid": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"current_package_guid": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"processes": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"process_guid": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"instances": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"memory": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"disk_quota": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
 			"disable_blue_green_deployment": &schema.Schema{
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -349,6 +570,43 @@ func resourceApp() *schema.Resource {
 							Default:      0,
 							ValidateFunc: validation.IntBetween(0, 15),
 						},
+						"pause_before_promotion": &schema.Schema{
+							Type:        schema.TypeInt,
+							Description: "Period (in seconds) to keep the candidate application on its staging routes before promotion.",
+							Optional:    true,
+							Default:     0,
+						},
+						"promotion_gate_script": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "Command polled until it exits 0 before the candidate application is promoted.",
+							Optional:    true,
+						},
+						"metric_gate": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"url": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"json_path": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"threshold": &schema.Schema{
+										Type:     schema.TypeFloat,
+										Required: true,
+									},
+									"settle_wait": &schema.Schema{
+										Type:     schema.TypeInt,
+										Optional: true,
+										Default:  10,
+									},
+								},
+							},
+						},
 						"staging_route": &schema.Schema{
 							Type:     schema.TypeSet,
 							Optional: true,
@@ -425,7 +683,7 @@ func resourceAppCreate(d *schema.ResourceData, meta interface{}) (err error) {
 		app cfapi.CCApp
 	)
 
-	app.Name = d.Get("name").(string)
+	app.Name = appliedName(d, session, d.Get("name").(string))
 	app.SpaceGUID = d.Get("space").(string)
 	if v, ok = d.GetOk("ports"); ok {
 		p := []int{}
@@ -474,9 +732,8 @@ func resourceAppCreate(d *schema.ResourceData, meta interface{}) (err error) {
 		vv := v.(int)
 		app.HealthCheckTimeout = &vv
 	}
-	if v, ok = d.GetOk("environment"); ok {
-		vv := v.(map[string]interface{})
-		app.Environment = &vv
+	if app.Environment, err = mergedAppEnvironment(d); err != nil {
+		return err
 	}
 	if v, ok = d.GetOk("docker_image"); ok {
 		vv := v.(string)
@@ -499,7 +756,11 @@ func resourceAppCreate(d *schema.ResourceData, meta interface{}) (err error) {
 	}
 
 	d.SetId(appConfig.app.ID)
-	setAppArguments(appConfig.app, d)
+	annotateManagedResource(session, "apps", appConfig.app.ID)
+	if d.Get("prevent_destroy_in_cf").(bool) {
+		setPreventDestroyAnnotation(session, "apps", appConfig.app.ID, true)
+	}
+	setAppArguments(appConfig.app, d, session)
 	if len(appConfig.serviceBindings) > 0 {
 		d.Set("service_binding", appConfig.serviceBindings)
 	}
@@ -525,6 +786,12 @@ func resourceAppCreateCfApp(d *schema.ResourceData, meta interface{}, appConfig
 	am := session.AppManager()
 	rm := session.RouteManager()
 
+	cleanup := newCleanupRegistry(session)
+	defer cleanup.runOnError(&err)
+
+	operationStarted := time.Now()
+	var uploadSize int64
+
 	app := appConfig.app
 	var (
 		v interface{}
@@ -544,10 +811,14 @@ func resourceAppCreateCfApp(d *schema.ResourceData, meta interface{}, appConfig
 
 	// Skip if Docker repo is given
 	if _, ok := d.GetOk("docker_image"); !ok {
-		appPath, err = prepareApp(app, d, session.Log)
+		appPath, err = prepareApp(app, d, session)
 		if err != nil {
 			return err
 		}
+		if v, ok := d.GetOk("url"); !ok || !strings.HasPrefix(v.(string), "file://") {
+			cleanup.registerPath("application artifact", appPath)
+		}
+		uploadSize, _ = artifactSize(appPath)
 	}
 
 	if v, hasRouteConfig = d.GetOk("route"); hasRouteConfig {
@@ -565,17 +836,9 @@ func resourceAppCreateCfApp(d *schema.ResourceData, meta interface{}, appConfig
 	}
 
 	// Delete application if an error occurs
-	defer func() {
-		e := &err
-		if *e == nil {
-			return
-		}
-		err2 := am.DeleteApp(app.ID, true)
-		fmt.Printf("Error while creating app %s (%s), the application has been deleted\n", app.Name, app.ID)
-		if err2 != nil {
-			err = err2
-		}
-	}()
+	cleanup.register(fmt.Sprintf("application '%s'", app.Name), func() error {
+		return am.DeleteApp(app.ID, true)
+	})
 
 	if v, ok := d.GetOk("add_content"); ok {
 		addContent = getListOfStructs(v)
@@ -610,7 +873,12 @@ func resourceAppCreateCfApp(d *schema.ResourceData, meta interface{}, appConfig
 
 	// Bind services
 	if v, hasServiceBindings = d.GetOk("service_binding"); hasServiceBindings {
-		if serviceBindings, err = addServiceBindings(app.ID, getListOfStructs(v), am, session.Log); err != nil {
+		if d.Get("wait_for_service_instances").(bool) {
+			if err = waitForServiceInstances(session, getListOfStructs(v)); err != nil {
+				return err
+			}
+		}
+		if serviceBindings, err = addServiceBindings(app.ID, getListOfStructs(v), session, d.Get("store_credentials_in_state").(bool)); err != nil {
 			return err
 		}
 	}
@@ -643,19 +911,53 @@ func resourceAppCreateCfApp(d *schema.ResourceData, meta interface{}, appConfig
 		}
 	}
 
-	timeout := time.Second * time.Duration(d.Get("timeout").(int))
+	timeout := appTimeout(d, session)
 	stopped := d.Get("stopped").(bool)
 
-	if _, ok := d.GetOk("docker_image"); ok {
+	if v, ok := d.GetOk("droplet_inspection"); ok {
+		inspection := v.([]interface{})[0].(map[string]interface{})
+		if err = inspectAppDroplet(app, inspection["command"].(string), am, session, appStagingTimeout(d, session)); err != nil {
+			return err
+		}
+	}
+
+	if d.Get("stage_only").(bool) {
+		// stage the uploaded package into a droplet without ever starting the
+		// app, for build-promote pipelines that deploy the droplet elsewhere
+		var dropletGUID string
+		if _, ok := d.GetOk("droplet_inspection"); ok {
+			// the inspection already staged the package
+			dropletGUID, err = am.GetCurrentDroplet(app.ID)
+		} else {
+			if dropletGUID, err = am.StageAppPackage(app.ID, appStagingTimeout(d, session)); err != nil {
+				return err
+			}
+			err = am.SetCurrentDroplet(app.ID, dropletGUID)
+		}
+		if err != nil {
+			return err
+		}
+		d.Set("droplet_guid", dropletGUID)
+	} else if _, ok := d.GetOk("docker_image"); ok {
 		if !stopped {
-			if err = am.StartDockerApp(app.ID, timeout); err != nil {
+			if !d.Get("wait_for_start").(bool) {
+				err = am.RequestAppStart(app.ID)
+			} else {
+				err = am.StartDockerApp(app.ID, timeout)
+			}
+			if err != nil {
 				return err
 			}
 		}
 	} else if !stopped {
 		// Start application if not stopped
 		// state once upload has completed
-		if err = am.StartApp(app.ID, timeout); err != nil {
+		if !d.Get("wait_for_start").(bool) {
+			err = am.RequestAppStart(app.ID)
+		} else {
+			err = am.StartApp(app.ID, timeout)
+		}
+		if err != nil {
 			return err
 		}
 	}
@@ -666,11 +968,28 @@ func resourceAppCreateCfApp(d *schema.ResourceData, meta interface{}, appConfig
 	appConfig.app = app
 	session.Log.DebugMessage("Created app state: %# v", app)
 
+	if err = setAppProcesses(app.ID, d, am); err != nil {
+		return err
+	}
+
+	if d.Get("log_rate_limit_in_bytes_per_second").(int) != -1 {
+		if err = applyAppLogRateLimit(app.ID, d, am, session); err != nil {
+			return err
+		}
+	}
+
 	if hasServiceBindings {
 		appConfig.serviceBindings = serviceBindings
 		session.Log.DebugMessage("Created service bindings: %# v", d.Get("service_binding"))
 	}
 
+	if !stopped && !d.Get("stage_only").(bool) && d.Get("wait_for_start").(bool) {
+		if err = runPostStartProbe(d, session, app.ID); err != nil {
+			return err
+		}
+	}
+
+	setAppOperationSummary(d, session, "create", operationStarted, uploadSize)
 	return err
 }
 
@@ -692,7 +1011,18 @@ func resourceAppRead(d *schema.ResourceData, meta interface{}) (err error) {
 			err = nil
 		}
 	} else {
-		setAppArguments(app, d)
+		setAppArguments(app, d, session)
+		if err = setAppProcesses(app.ID, d, am); err != nil {
+			return err
+		}
+		if err = setAppInstanceStats(app, d, am, session.Log); err != nil {
+			return err
+		}
+		if d.Get("stage_only").(bool) {
+			if dropletGUID, err := am.GetCurrentDroplet(app.ID); err == nil {
+				d.Set("droplet_guid", dropletGUID)
+			}
+		}
 	}
 
 	if _, hasOldRoute := d.GetOk("route"); hasOldRoute {
@@ -757,7 +1087,7 @@ func resourceAppRead(d *schema.ResourceData, meta interface{}) (err error) {
 							break
 						}
 					}
-					if !replacementFound{
+					if !replacementFound {
 						session.Log.DebugMessage("No replacement route mapping found! Delete route mapping %s from .tfstate!", mappingID)
 					}
 				} else {
@@ -798,6 +1128,14 @@ func resourceAppUpdate(d *schema.ResourceData, meta interface{}) (err error) {
 		return fmt.Errorf("client is nil")
 	}
 
+	if err = validateManagedResource(session, "apps", d.Id()); err != nil {
+		return err
+	}
+
+	if d.HasChange("prevent_destroy_in_cf") {
+		setPreventDestroyAnnotation(session, "apps", d.Id(), d.Get("prevent_destroy_in_cf").(bool))
+	}
+
 	// TODO: clean-up old deposed resources
 
 	app := cfapi.CCApp{
@@ -806,7 +1144,7 @@ func resourceAppUpdate(d *schema.ResourceData, meta interface{}) (err error) {
 	d.Partial(true)
 
 	update := false // for changes where no restart is required
-	app.Name = *getChangedValueString("name", &update, d)
+	app.Name = appliedName(d, session, *getChangedValueString("name", &update, d))
 	app.SpaceGUID = *getChangedValueString("space", &update, d)
 	app.Instances = getChangedValueInt("instances", &update, d)
 	app.EnableSSH = getChangedValueBool("enable_ssh", &update, d)
@@ -823,6 +1161,22 @@ func resourceAppUpdate(d *schema.ResourceData, meta interface{}) (err error) {
 	restage := false // for changes where a full restage is required
 	app.Buildpack = getChangedValueString("buildpack", &restage, d)
 	app.Environment = getChangedValueMap("environment", &restage, d)
+	if _, ok := d.GetOk("environment_file"); ok {
+		if app.Environment != nil ||
+			d.HasChange("environment_file") || d.HasChange("environment_file_hash") {
+			if app.Environment, err = mergedAppEnvironment(d); err != nil {
+				return err
+			}
+			restage = true
+		}
+	}
+
+	// a stack change is applied in place via the V3 lifecycle API and needs
+	// a restage to rebuild the droplet on the new stack
+	stackChange := d.HasChange("stack")
+	if stackChange {
+		restage = true
+	}
 
 	// Notes about docker images
 	// Diego appears to restart applications by itself when only the docker_image
@@ -851,17 +1205,37 @@ func resourceAppUpdate(d *schema.ResourceData, meta interface{}) (err error) {
 		}
 	}
 
+	if restart || restage || blueGreen {
+		// restarts and restages are only permitted within the configured
+		// maintenance window
+		if err = checkAppMaintenanceWindow(d, session); err != nil {
+			return err
+		}
+	}
+
 	if blueGreen {
 		if routes, ok := d.GetOk("routes"); !ok || routes.(*schema.Set).Len() < 1 {
 			// TODO: add test to ensure this check is done
 			return fmt.Errorf("Blue/green mode requires a 'routes' block.")
 		}
+		// the green app is created from scratch and picks up the new stack there
 		err = resourceAppBlueGreenUpdate(d, meta, app)
 	} else {
+		if stackChange {
+			if v, ok := d.GetOk("stack"); ok {
+				if err = session.AppManager().UpdateAppStackLifecycle(app.ID, v.(string)); err != nil {
+					return err
+				}
+				d.SetPartial("stack")
+			}
+		}
 		// fall back to a standard update to the existing app
 		err = resourceAppStandardUpdate(d, meta, app, update, restart, restage)
 	}
 
+	if err == nil && !d.Get("stopped").(bool) && d.Get("wait_for_start").(bool) {
+		err = runPostStartProbe(d, session, d.Id())
+	}
 	if err == nil {
 		d.Partial(false)
 	}
@@ -905,16 +1279,37 @@ func resourceAppBlueGreenUpdate(d *schema.ResourceData, meta interface{}, newApp
 	appConfig.app.Instances = newApp.Instances // restore final expected instances count
 	newApp = appConfig.app                     // bring "newApp" var up-to-date, to help prevent bugs
 
-	// TODO: Execute blue-green validation, including mapping staging route(s)!
+	// map the candidate to its staging route(s), if any were configured, so
+	// it is reachable for smoke testing while the promotion gate holds
+	var stagingRoutes []interface{}
+	if v, ok := blueGreenConfig["staging_route"]; ok {
+		if mappedRoutes, err := addRouteMappings(appConfig.app.ID, v.(*schema.Set).List(), appConfig.app.ID, rm); err != nil {
+			return err
+		} else {
+			stagingRoutes = mappedRoutes
+		}
+	}
+
+	// hold the candidate on its staging routes until the optional
+	// pre-promotion approval gate is satisfied
+	if err := waitForPromotionGate(session, blueGreenConfig, appConfig.app, appTimeout(d, session)); err != nil {
+		return err
+	}
+
+	// TODO: Execute blue-green validation!
 
 	// now that we've passed validation, we've passed the point of no return
 	d.SetId(appConfig.app.ID)
+	annotateManagedResource(session, "apps", appConfig.app.ID)
+	if d.Get("prevent_destroy_in_cf").(bool) {
+		setPreventDestroyAnnotation(session, "apps", appConfig.app.ID, true)
+	}
 	d.SetPartial("url")
 	d.SetPartial("git")
 	d.SetPartial("github_release")
 	d.SetPartial("add_content")
 	d.SetPartial("service_binding")
-	setAppArguments(appConfig.app, d)
+	setAppArguments(appConfig.app, d, session)
 
 	// ensure we keep track of the old application to clean it up later if we fail
 	deposedResources := d.Get("deposed").(map[string]interface{})
@@ -929,12 +1324,15 @@ func resourceAppBlueGreenUpdate(d *schema.ResourceData, meta interface{}, newApp
 	}
 	d.SetPartial("route")
 
-	var timeoutDuration time.Duration
-	if v, ok := d.GetOk("timeout"); ok {
-		vv := v.(int)
-		timeoutDuration = time.Second * time.Duration(vv)
+	// the candidate now serves on the live routes, so retire its staging route(s)
+	if len(stagingRoutes) > 0 {
+		if err := deleteRouteMappings(stagingRoutes, rm); err != nil {
+			return err
+		}
 	}
 
+	timeoutDuration := appTimeout(d, session)
+
 	shutdownWaitTime := time.Duration(0)
 	if v, ok := blueGreenConfig["shutdown_wait"]; ok {
 		shutdownWaitTime = time.Duration(v.(int)) * time.Minute
@@ -973,10 +1371,17 @@ func resourceAppBlueGreenUpdate(d *schema.ResourceData, meta interface{}, newApp
 					Timeout:      timeoutDuration,
 					PollInterval: 5 * time.Second,
 				}
-				if _, err := stateConf.WaitForState(); err != nil {
+				if _, err := waitForStateWithBackoff(stateConf); err != nil {
 					return err
 				}
 			}
+
+			// with a metric gate configured, sample the metrics endpoint
+			// after each scale step and halt the rollout while the venerable
+			// app still carries traffic if the threshold is exceeded
+			if err := checkMetricGate(session, blueGreenConfig, newAppScale); err != nil {
+				return err
+			}
 		}
 
 		if shutdownWaitTime <= 0 {
@@ -999,7 +1404,7 @@ func resourceAppBlueGreenUpdate(d *schema.ResourceData, meta interface{}, newApp
 						Timeout:      timeoutDuration,
 						PollInterval: 5 * time.Second,
 					}
-					if _, err := stateConf.WaitForState(); err != nil {
+					if _, err := waitForStateWithBackoff(stateConf); err != nil {
 						return err
 					}
 					// CF gives shutting down processes at most 10 seconds to exit
@@ -1043,6 +1448,10 @@ func resourceAppBlueGreenUpdate(d *schema.ResourceData, meta interface{}, newApp
 }
 
 func resourceAppStandardUpdate(d *schema.ResourceData, meta interface{}, app cfapi.CCApp, update bool, restart bool, restage bool) error {
+
+	operationStarted := time.Now()
+	var uploadSize int64
+
 	session := meta.(*cfapi.Session)
 	if session == nil {
 		return fmt.Errorf("client is nil")
@@ -1059,7 +1468,7 @@ func resourceAppStandardUpdate(d *schema.ResourceData, meta interface{}, app cfa
 		if app, err = am.UpdateApp(app); err != nil {
 			return err
 		}
-		setAppArguments(app, d)
+		setAppArguments(app, d, session)
 		d.SetPartial("name")
 		d.SetPartial("space")
 		d.SetPartial("ports")
@@ -1075,6 +1484,13 @@ func resourceAppStandardUpdate(d *schema.ResourceData, meta interface{}, app cfa
 		d.SetPartial("environment")
 	}
 
+	if d.HasChange("log_rate_limit_in_bytes_per_second") {
+		if err := applyAppLogRateLimit(app.ID, d, am, session); err != nil {
+			return err
+		}
+		d.SetPartial("log_rate_limit_in_bytes_per_second")
+	}
+
 	// update the application's service bindings (the necessary restage is dealt with later)
 	if d.HasChange("service_binding") {
 
@@ -1090,7 +1506,12 @@ func resourceAppStandardUpdate(d *schema.ResourceData, meta interface{}, app cfa
 			return err
 		}
 
-		if added, err := addServiceBindings(app.ID, bindingsToAdd, am, session.Log); err != nil {
+		if d.Get("wait_for_service_instances").(bool) {
+			if err := waitForServiceInstances(session, bindingsToAdd); err != nil {
+				return err
+			}
+		}
+		if added, err := addServiceBindings(app.ID, bindingsToAdd, session, d.Get("store_credentials_in_state").(bool)); err != nil {
 			return err
 		} else if len(added) > 0 {
 			if new != nil {
@@ -1100,6 +1521,12 @@ func resourceAppStandardUpdate(d *schema.ResourceData, meta interface{}, app cfa
 					for _, a := range added {
 						if bb["service_instance"] == a["service_instance"] {
 							bb["binding_id"] = a["binding_id"]
+							if v, ok := a["credentials"]; ok {
+								bb["credentials"] = v
+							}
+							if v, ok := a["credentials_hash"]; ok {
+								bb["credentials_hash"] = v
+							}
 							break
 						}
 					}
@@ -1233,7 +1660,12 @@ func resourceAppStandardUpdate(d *schema.ResourceData, meta interface{}, app cfa
 			if err := validateRoute(app.ID, routeID, rm); err != nil {
 				return err
 			}
-			if mappingID, err := rm.CreateRouteMapping(routeID, app.ID, nil); err != nil {
+			// map the route to the configured container port, if one was given
+			var appPort *int
+			if v, ok := data["port"].(int); ok && v > 0 {
+				appPort = &v
+			}
+			if mappingID, err := rm.CreateRouteMapping(routeID, app.ID, appPort); err != nil {
 				return err
 			} else {
 				data["mapping_id"] = mappingID
@@ -1272,20 +1704,51 @@ func resourceAppStandardUpdate(d *schema.ResourceData, meta interface{}, app cfa
 			}
 		}
 
-		// mappings which may need updating
-		// TODO: need to implement this in order to handle the port and exclusive fields
-		/* oldDataList := os.Intersection(ns).List()
+		// mappings which may need updating; the set hashes on the route GUID
+		// only, so a port change on an existing mapping lands here rather
+		// than in the difference sets above
+		oldDataList := os.Intersection(ns).List()
 		for i, r := range ns.Intersection(os).List() {
 			oldData := oldDataList[i].(map[string]interface{})
 			newData := r.(map[string]interface{})
 
-			if !reflect.DeepEqual(oldData, newData) {
-
+			oldPort, _ := oldData["port"].(int)
+			newPort, _ := newData["port"].(int)
+			if newPort == 0 || newPort == oldPort {
+				continue
 			}
-		} */
-
-		d.SetPartial("routes") // routes updates complete, save them to state
-	}
+
+			// route mappings are immutable in CC, so recreate the mapping
+			// against the new container port
+			routeID := newData["route"].(string)
+			if mappingID, ok := oldData["mapping_id"].(string); ok && len(mappingID) > 0 {
+				if err := rm.DeleteRouteMapping(mappingID); err != nil {
+					if !strings.Contains(err.Error(), "status code: 404") {
+						return err
+					}
+				}
+			}
+			appPort := newPort
+			if mappingID, err := rm.CreateRouteMapping(routeID, app.ID, &appPort); err != nil {
+				return err
+			} else {
+				newData["mapping_id"] = mappingID
+			}
+			// read mapping port
+			if mapping, err := rm.ReadRouteMapping(newData["mapping_id"].(string)); err != nil {
+				return err
+			} else {
+				newData["port"] = mapping.AppPort
+			}
+			updatedRoutes.Remove(oldData)
+			updatedRoutes.Add(newData)
+			if err := d.Set("routes", updatedRoutes); err != nil {
+				return err
+			}
+		}
+
+		d.SetPartial("routes") // routes updates complete, save them to state
+	}
 
 	binaryUpdated := false // check if we need to update the application's binary
 	if d.HasChange("url") || d.HasChange("git") || d.HasChange("github_release") || d.HasChange("add_content") {
@@ -1299,7 +1762,7 @@ func resourceAppStandardUpdate(d *schema.ResourceData, meta interface{}, app cfa
 			addContent []map[string]interface{}
 		)
 
-		if appPathCalc, err := prepareApp(app, d, session.Log); err != nil {
+		if appPathCalc, err := prepareApp(app, d, session); err != nil {
 			return err
 		} else {
 			appPath = appPathCalc
@@ -1311,6 +1774,7 @@ func resourceAppStandardUpdate(d *schema.ResourceData, meta interface{}, app cfa
 			addContent = getListOfStructs(v)
 		}
 
+		uploadSize, _ = artifactSize(appPath)
 		if err := am.UploadApp(app, appPath, addContent); err != nil {
 			return err
 		}
@@ -1318,7 +1782,7 @@ func resourceAppStandardUpdate(d *schema.ResourceData, meta interface{}, app cfa
 	}
 
 	// now that all of the reconfiguration is done, we can deal doing a restage or restart, as required
-	timeout := time.Second * time.Duration(d.Get("timeout").(int))
+	timeout := appTimeout(d, session)
 
 	// check the package state of the application after binary upload
 	var curApp cfapi.CCApp
@@ -1344,11 +1808,28 @@ func resourceAppStandardUpdate(d *schema.ResourceData, meta interface{}, app cfa
 		}
 	}
 
+	if d.Get("stage_only").(bool) {
+		// a staged-but-stopped app is never restarted; new bits are staged
+		// into a fresh droplet instead
+		if binaryUpdated || restage || restart {
+			dropletGUID, err := am.StageAppPackage(app.ID, appStagingTimeout(d, session))
+			if err != nil {
+				return err
+			}
+			if err = am.SetCurrentDroplet(app.ID, dropletGUID); err != nil {
+				return err
+			}
+			d.Set("droplet_guid", dropletGUID)
+		}
+		restage = false
+		restart = false
+	}
+
 	if restage {
-		if err := am.RestageApp(app.ID, timeout); err != nil {
+		if err := am.RestageApp(app.ID, appStagingTimeout(d, session)); err != nil {
 			return err
 		}
-		if *curApp.State == "STARTED" {
+		if *curApp.State == "STARTED" && d.Get("wait_for_start").(bool) {
 			// if the app was running before the restage when wait for it to start again
 			if err := am.WaitForAppToStart(app, timeout); err != nil {
 				return err
@@ -1359,21 +1840,33 @@ func resourceAppStandardUpdate(d *schema.ResourceData, meta interface{}, app cfa
 			if err := am.StopDockerApp(app.ID, timeout); err != nil {
 				return err
 			}
-			if err := am.StartDockerApp(app.ID, timeout); err != nil {
+			var err error
+			if !d.Get("wait_for_start").(bool) {
+				err = am.RequestAppStart(app.ID)
+			} else {
+				err = am.StartDockerApp(app.ID, timeout)
+			}
+			if err != nil {
 				return err
 			}
 		} else {
 			if err := am.StopApp(app.ID, timeout); err != nil {
 				return err
 			}
-			if err := am.StartApp(app.ID, timeout); err != nil {
+			var err error
+			if !d.Get("wait_for_start").(bool) {
+				err = am.RequestAppStart(app.ID)
+			} else {
+				err = am.StartApp(app.ID, timeout)
+			}
+			if err != nil {
 				return err
 			}
 		}
 	}
 
 	// now set the final started/stopped state, whatever it is
-	if d.HasChange("stopped") {
+	if d.HasChange("stopped") && !d.Get("stage_only").(bool) {
 		if d.Get("stopped").(bool) {
 			if _, ok := d.GetOk("docker_image"); ok {
 				if err := am.StopDockerApp(app.ID, timeout); err != nil {
@@ -1385,7 +1878,11 @@ func resourceAppStandardUpdate(d *schema.ResourceData, meta interface{}, app cfa
 				}
 			}
 		} else {
-			if _, ok := d.GetOk("docker_image"); ok {
+			if !d.Get("wait_for_start").(bool) {
+				if err := am.RequestAppStart(app.ID); err != nil {
+					return err
+				}
+			} else if _, ok := d.GetOk("docker_image"); ok {
 				if err := am.StartDockerApp(app.ID, timeout); err != nil {
 					return err
 				}
@@ -1397,6 +1894,8 @@ func resourceAppStandardUpdate(d *schema.ResourceData, meta interface{}, app cfa
 		}
 	}
 
+	setAppOperationSummary(d, session, "update", operationStarted, uploadSize)
+
 	// We succeeded, disable partial mode
 	d.Partial(false)
 	return nil
@@ -1409,6 +1908,13 @@ func resourceAppDelete(d *schema.ResourceData, meta interface{}) (err error) {
 		return fmt.Errorf("client is nil")
 	}
 
+	if err = validateDestroyAllowed(d, "apps"); err != nil {
+		return err
+	}
+	if err = validateManagedResource(session, "apps", d.Id()); err != nil {
+		return err
+	}
+
 	am := session.AppManager()
 	rm := session.RouteManager()
 
@@ -1458,9 +1964,9 @@ func resourceAppDelete(d *schema.ResourceData, meta interface{}) (err error) {
 	return nil
 }
 
-func setAppArguments(app cfapi.CCApp, d *schema.ResourceData) {
+func setAppArguments(app cfapi.CCApp, d *schema.ResourceData, session *cfapi.Session) {
 
-	d.Set("name", app.Name)
+	d.Set("name", strippedName(d, session, app.Name))
 	d.Set("space", app.SpaceGUID)
 	if app.Instances != nil || IsImportState(d) {
 		d.Set("instances", app.Instances)
@@ -1493,7 +1999,25 @@ func setAppArguments(app cfapi.CCApp, d *schema.ResourceData) {
 		d.Set("health_check_timeout", app.HealthCheckTimeout)
 	}
 	if app.Environment != nil || IsImportState(d) {
-		d.Set("environment", app.Environment)
+		env := app.Environment
+		if v, ok := d.GetOk("environment_file"); ok && env != nil {
+			// keep only inline variables in state so file-sourced ones do not
+			// show up as a perpetual diff against the 'environment' map
+			if fileEnv, err := parseEnvironmentFile(v.(string)); err == nil {
+				inline := d.Get("environment").(map[string]interface{})
+				stripped := make(map[string]interface{})
+				for k, vv := range *env {
+					if _, fromFile := fileEnv[k]; fromFile {
+						if _, isInline := inline[k]; !isInline {
+							continue
+						}
+					}
+					stripped[k] = vv
+				}
+				env = &stripped
+			}
+		}
+		d.Set("environment", env)
 	}
 
 	d.SetPartial("timeout")
@@ -1506,7 +2030,25 @@ func setAppArguments(app cfapi.CCApp, d *schema.ResourceData) {
 	d.Set("ports", schema.NewSet(resourceIntegerSet, ports))
 }
 
-func prepareApp(app cfapi.CCApp, d *schema.ResourceData, log *cfapi.Logger) (path string, err error) {
+func prepareApp(app cfapi.CCApp, d *schema.ResourceData, session *cfapi.Session) (path string, err error) {
+
+	log := session.Log
+
+	cacheKey := appArtifactCacheKey(d)
+	if path, err = cachedArtifact(session, cacheKey); err != nil {
+		return "", err
+	} else if len(path) > 0 {
+		log.UI.Say("Using cached artifact for application %s.", terminal.EntityNameColor(app.Name))
+		if err = checkMaxUploadSize(session, path); err != nil {
+			return "", err
+		}
+		return path, nil
+	}
+
+	workDir, err := appWorkDir(d, session)
+	if err != nil {
+		return "", err
+	}
 
 	if v, ok := d.GetOk("url"); ok {
 		url := v.(string)
@@ -1522,7 +2064,7 @@ func prepareApp(app cfapi.CCApp, d *schema.ResourceData, log *cfapi.Logger) (pat
 				out *os.File
 			)
 
-			if out, err = ioutil.TempFile("", "cfapp"); err != nil {
+			if out, err = ioutil.TempFile(workDir, "cfapp"); err != nil {
 				return "", err
 			}
 
@@ -1532,6 +2074,13 @@ func prepareApp(app cfapi.CCApp, d *schema.ResourceData, log *cfapi.Logger) (pat
 				return "", err
 			}
 			in = resp.Body
+			// fail before the download when the advertised size does not fit
+			// into the work directory
+			if err = checkFreeDiskSpace(workDir, resp.ContentLength); err != nil {
+				in.Close()
+				os.Remove(out.Name())
+				return "", err
+			}
 			if _, err = io.Copy(out, in); err != nil {
 				return "", err
 			}
@@ -1560,7 +2109,12 @@ func prepareApp(app cfapi.CCApp, d *schema.ResourceData, log *cfapi.Logger) (pat
 		return "", err
 	}
 
+	if err = checkMaxUploadSize(session, path); err != nil {
+		return "", err
+	}
+
 	log.UI.Say("Application downloaded to: %s", path)
+	cacheArtifact(session, cacheKey, path)
 	return path, nil
 }
 
@@ -1587,7 +2141,12 @@ func addRouteMappings(appID string, routes []interface{}, validCurrentAppMapping
 		if err := validateRoute(validCurrentAppMapping, routeID, rm); err != nil {
 			return nil, err
 		}
-		if mappingID, err := rm.CreateRouteMapping(routeID, appID, nil); err != nil {
+		// map the route to the configured container port, if one was given
+		var appPort *int
+		if v, ok := data["port"].(int); ok && v > 0 {
+			appPort = &v
+		}
+		if mappingID, err := rm.CreateRouteMapping(routeID, appID, appPort); err != nil {
 			return nil, err
 		} else {
 			data["mapping_id"] = mappingID
@@ -1679,16 +2238,65 @@ func updateAppRouteMappings(
 	return mappingID, err
 }
 
+// waitForServiceInstances - polls the service instances referenced by the
+// given bindings until any in-flight asynchronous operation has finished, so
+// bindings are not attempted against instances that are still provisioning
+func waitForServiceInstances(session *cfapi.Session, bindings []map[string]interface{}) (err error) {
+
+	sm := session.ServiceManager()
+
+	timeout := 15 * time.Minute
+	if session.DefaultServiceTimeout > 0 {
+		timeout = time.Second * time.Duration(session.DefaultServiceTimeout)
+	}
+	deadline := time.Now().Add(timeout)
+
+	for _, b := range bindings {
+		serviceInstanceID := b["service_instance"].(string)
+		for {
+			serviceInstance, err := sm.ReadServiceInstance(serviceInstanceID)
+			if err != nil {
+				return err
+			}
+
+			state, _ := serviceInstance.LastOperation["state"].(string)
+			operation, _ := serviceInstance.LastOperation["type"].(string)
+			if state != "in progress" {
+				if state == "failed" {
+					description, _ := serviceInstance.LastOperation["description"].(string)
+					return fmt.Errorf(
+						"service instance '%s' cannot be bound as its last %s operation failed: %s",
+						serviceInstanceID, operation, description)
+				}
+				break
+			}
+			if time.Now().After(deadline) {
+				return fmt.Errorf(
+					"timed out waiting for service instance '%s' to finish its %s operation",
+					serviceInstanceID, operation)
+			}
+			session.Log.DebugMessage(
+				"Waiting for service instance '%s' to finish its %s operation before binding.",
+				serviceInstanceID, operation)
+			time.Sleep(10 * time.Second)
+		}
+	}
+	return nil
+}
+
 func addServiceBindings(
 	id string,
 	add []map[string]interface{},
-	am *cfapi.AppManager,
-	log *cfapi.Logger) (bindings []map[string]interface{}, err error) {
+	session *cfapi.Session,
+	storeCredentials bool) (bindings []map[string]interface{}, err error) {
 
 	var (
 		serviceInstanceID, bindingID string
 		params                       *map[string]interface{}
 	)
+	am := session.AppManager()
+	sm := session.ServiceManager()
+	log := session.Log
 
 	for _, b := range add {
 		serviceInstanceID = b["service_instance"].(string)
@@ -1697,10 +2305,41 @@ func addServiceBindings(
 			vv := v.(map[string]interface{})
 			params = &vv
 		}
-		if bindingID, _, err = am.CreateServiceBinding(id, serviceInstanceID, params); err != nil {
+
+		// user-provided instances are not visible on the managed service
+		// instance endpoint and are bound via V3, which unlike V2 accepts
+		// binding parameters for them
+		userProvided := false
+		if _, err = sm.ReadServiceInstance(serviceInstanceID); err != nil {
+			if !strings.Contains(err.Error(), "status code: 404") {
+				return bindings, err
+			}
+			userProvided = true
+		}
+
+		var credentials map[string]interface{}
+		if userProvided {
+			bindingID, credentials, err = am.CreateUserProvidedServiceBinding(id, serviceInstanceID, params)
+		} else {
+			var release func()
+			if release, err = acquireServiceBrokerSlotForInstance(session, serviceInstanceID); err != nil {
+				return bindings, err
+			}
+			bindingID, credentials, err = am.CreateServiceBinding(id, serviceInstanceID, params)
+			release()
+		}
+		if err != nil {
 			return bindings, err
 		}
 		b["binding_id"] = bindingID
+		if len(credentials) > 0 {
+			// the hash is always kept so rotated credentials are detected
+			// even when the values themselves are kept out of state
+			b["credentials_hash"] = credentialsHash(credentials)
+			if storeCredentials {
+				b["credentials"] = normalizeMap(credentials, make(map[string]interface{}), "", "_")
+			}
+		}
 
 		bindings = append(bindings, b)
 		log.DebugMessage("Created binding with id '%s' for service instance '%s'.", bindingID, serviceInstanceID)
@@ -1708,6 +2347,18 @@ func addServiceBindings(
 	return bindings, nil
 }
 
+// credentialsHash - a stable sha256 fingerprint of binding credentials, for
+// drift detection when the credential values are kept out of state
+func credentialsHash(credentials map[string]interface{}) string {
+
+	// map keys are marshalled in sorted order so the digest is stable
+	raw, err := json.Marshal(credentials)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(raw))
+}
+
 func removeServiceBindings(delete []map[string]interface{},
 	am *cfapi.AppManager, log *cfapi.Logger) error {
 
@@ -1730,3 +2381,721 @@ func removeServiceBindings(delete []map[string]interface{},
 	}
 	return nil
 }
+
+// setAppProcesses - exposes the app's V3 processes, the GUID of its web
+// process and its current droplet and package GUIDs as computed attributes
+func setAppProcesses(appID string, d *schema.ResourceData, am *cfapi.AppManager) (err error) {
+
+	// apps that never staged have neither a droplet nor a package yet, so
+	// lookup failures just leave the attributes empty
+	if dropletGUID, err := am.GetCurrentDroplet(appID); err == nil {
+		d.Set("current_droplet_guid", dropletGUID)
+	}
+	if packageGUID, err := am.GetCurrentPackage(appID); err == nil {
+		d.Set("current_package_guid", packageGUID)
+	}
+
+	processes, err := am.ReadAppProcesses(appID)
+	if err != nil {
+		return err
+	}
+
+	list := []interface{}{}
+	for _, p := range processes {
+		if p.Type == "web" {
+			d.Set("web_process_guid", p.GUID)
+			if p.LogRateLimit != nil {
+				d.Set("log_rate_limit_in_bytes_per_second", int(*p.LogRateLimit))
+			}
+		}
+		list = append(list, map[string]interface{}{
+			"process_guid": p.GUID,
+			"type":         p.Type,
+			"instances":    p.Instances,
+			"memory":       p.MemoryInMB,
+			"disk_quota":   p.DiskInMB,
+		})
+	}
+	d.Set("processes", list)
+	return nil
+}
+
+// applyAppLogRateLimit - applies 'log_rate_limit_in_bytes_per_second' to the
+// app's web process; Cloud Controllers that predate log rate limits do not
+// report the field on processes, in which case the setting is skipped with a
+// debug message rather than failing the apply
+func applyAppLogRateLimit(appID string, d *schema.ResourceData, am *cfapi.AppManager, session *cfapi.Session) error {
+
+	limit := int64(d.Get("log_rate_limit_in_bytes_per_second").(int))
+
+	processes, err := am.ReadAppProcesses(appID)
+	if err != nil {
+		return err
+	}
+	for _, p := range processes {
+		if p.Type != "web" {
+			continue
+		}
+		if p.LogRateLimit == nil {
+			session.Log.DebugMessage(
+				"Skipping log rate limit for app %s, the Cloud Controller does not support it.", appID)
+			return nil
+		}
+		if *p.LogRateLimit == limit {
+			return nil
+		}
+		return am.UpdateProcessLogRateLimit(p.GUID, limit)
+	}
+	return nil
+}
+
+// setAppInstanceStats - exposes the observed instance state and staging time
+// of the app so external monitors can be generated from its state
+func setAppInstanceStats(app cfapi.CCApp, d *schema.ResourceData, am *cfapi.AppManager, log *cfapi.Logger) (err error) {
+
+	running, crashed := 0, 0
+	if app.State != nil && *app.State == cfapi.AppStarted {
+		// instance stats are only reported for started apps
+		if running, crashed, err = am.CountAppInstanceStates(app); err != nil {
+			log.DebugMessage("Unable to read instance states of app %s: %s", app.ID, err.Error())
+			err = nil
+		}
+	}
+	d.Set("running_instances", running)
+	d.Set("crashed_instances", crashed)
+
+	lastStagedAt, err := am.ReadAppPackageUpdatedAt(app.ID)
+	if err != nil {
+		return err
+	}
+	d.Set("last_staged_at", lastStagedAt)
+	return nil
+}
+
+// appTimeout - resolves the startup wait for an app falling back to the
+// provider wide default when the resource does not set its own timeout
+func appTimeout(d *schema.ResourceData, session *cfapi.Session) time.Duration {
+	timeout := d.Get("timeout").(int)
+	if timeout <= 0 {
+		timeout = session.DefaultAppTimeout
+	}
+	if timeout <= 0 {
+		timeout = DefaultAppTimeout
+	}
+	return time.Second * time.Duration(timeout)
+}
+
+// appStagingTimeout - resolves the wait for staging operations, preferring
+// the provider wide staging default over the app startup timeout
+func appStagingTimeout(d *schema.ResourceData, session *cfapi.Session) time.Duration {
+	if _, ok := d.GetOk("timeout"); !ok && session.DefaultStagingTimeout > 0 {
+		return time.Second * time.Duration(session.DefaultStagingTimeout)
+	}
+	return appTimeout(d, session)
+}
+
+// inspectAppDroplet - stages the uploaded package, downloads the resulting
+// droplet to a temporary file and runs the configured inspection command
+// against it, aborting the apply on a non-zero exit
+func inspectAppDroplet(app cfapi.CCApp, command string, am *cfapi.AppManager, session *cfapi.Session, timeout time.Duration) (err error) {
+
+	dropletGUID, err := am.StageAppPackage(app.ID, timeout)
+	if err != nil {
+		return err
+	}
+	if err = am.SetCurrentDroplet(app.ID, dropletGUID); err != nil {
+		return err
+	}
+
+	dropletFile, err := ioutil.TempFile("", "droplet")
+	if err != nil {
+		return err
+	}
+	dropletFile.Close()
+	defer os.Remove(dropletFile.Name())
+
+	if err = am.DownloadDroplet(app.ID, dropletFile.Name()); err != nil {
+		return err
+	}
+
+	session.Log.UI.Say("Running droplet inspection for %s...", terminal.EntityNameColor(app.Name))
+
+	cmd := shellCommand(command)
+	cmd.Env = append(os.Environ(),
+		"CF_DROPLET_PATH="+dropletFile.Name(),
+		"CF_APP_NAME="+app.Name,
+		"CF_APP_GUID="+app.ID)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("droplet inspection of app %s failed: %s\n%s", app.Name, err.Error(), string(output))
+	}
+	return nil
+}
+
+func resourceAppCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) (err error) {
+
+	session, ok := meta.(*cfapi.Session)
+	if !ok || session == nil {
+		return nil
+	}
+
+	if err = validateAppQuotaDiff(diff, session); err != nil {
+		return err
+	}
+	if err = validateAppBuildpackDiff(diff, session); err != nil {
+		return err
+	}
+	if err = setAppEnvironmentFileDiff(diff); err != nil {
+		return err
+	}
+	return validateAppSSHDiff(diff, session)
+}
+
+// setAppEnvironmentFileDiff - hashes the parsed 'environment_file' into the
+// computed 'environment_file_hash' so edits to the file surface as a diff and
+// trigger a restage, and so an unreadable or malformed file fails the plan
+func setAppEnvironmentFileDiff(diff *schema.ResourceDiff) error {
+
+	v, ok := diff.GetOk("environment_file")
+	if !ok {
+		return nil
+	}
+	env, err := parseEnvironmentFile(v.(string))
+	if err != nil {
+		return err
+	}
+	return diff.SetNew("environment_file_hash", environmentFileHash(env))
+}
+
+// validateAppBuildpackDiff - checks that a buildpack referenced by name is
+// installed for the app's stack, catching "buildpack not found for stack"
+// staging failures at plan time; buildpacks referenced by URL are fetched
+// during staging and cannot be checked
+func validateAppBuildpackDiff(diff *schema.ResourceDiff, session *cfapi.Session) (err error) {
+
+	v, ok := diff.GetOk("buildpack")
+	if !ok {
+		return nil
+	}
+	buildpack := v.(string)
+	if strings.Contains(buildpack, "://") || strings.Contains(buildpack, "/") {
+		return nil
+	}
+
+	stackName := ""
+	if v, ok := diff.GetOk("stack"); ok {
+		stack, err := session.StackManager().FindStack(v.(string))
+		if err != nil {
+			session.Log.DebugMessage("Skipping buildpack check, unable to read stack %s: %s", v.(string), err.Error())
+			return nil
+		}
+		stackName = stack.Name
+	}
+
+	buildpacks, err := session.BuildpackManager().ListBuildpacks()
+	if err != nil {
+		session.Log.DebugMessage("Skipping buildpack check, unable to list buildpacks: %s", err.Error())
+		return nil
+	}
+
+	matchedName := false
+	for _, bp := range buildpacks {
+		if bp.Name != buildpack {
+			continue
+		}
+		matchedName = true
+		// a buildpack without a stack applies to all stacks
+		if len(stackName) == 0 || len(bp.Stack) == 0 || bp.Stack == stackName {
+			return nil
+		}
+	}
+	if !matchedName {
+		return fmt.Errorf("buildpack '%s' is not installed on the platform", buildpack)
+	}
+	return fmt.Errorf("buildpack '%s' is not available for stack '%s'", buildpack, stackName)
+}
+
+// validateAppQuotaDiff - validates the requested memory allocation against
+// the org and space quotas at plan time, so a deployment that cannot fit
+// fails before any blue/green staging has started
+func validateAppQuotaDiff(diff *schema.ResourceDiff, session *cfapi.Session) (err error) {
+
+	memory, ok := diff.GetOk("memory")
+	if !ok {
+		return nil
+	}
+	instances := diff.Get("instances").(int)
+	if instances <= 0 {
+		instances = 1
+	}
+	requested := int64(memory.(int) * instances)
+
+	spaceID := diff.Get("space").(string)
+	if len(spaceID) == 0 {
+		// the space is created in the same plan so its quotas cannot
+		// be inspected yet
+		return nil
+	}
+	space, err := session.SpaceManager().ReadSpace(spaceID)
+	if err != nil {
+		session.Log.DebugMessage("Skipping quota check, unable to read space %s: %s", spaceID, err.Error())
+		return nil
+	}
+	org, err := session.OrgManager().ReadOrg(space.OrgGUID)
+	if err != nil {
+		session.Log.DebugMessage("Skipping quota check, unable to read org %s: %s", space.OrgGUID, err.Error())
+		return nil
+	}
+
+	qm := session.QuotaManager()
+
+	if len(space.QuotaGUID) > 0 {
+		quota, err := qm.ReadQuota(cfapi.SpaceQuota, space.QuotaGUID)
+		if err != nil {
+			return err
+		}
+		if quota.InstanceMemoryLimit >= 0 && int64(memory.(int)) > quota.InstanceMemoryLimit {
+			return fmt.Errorf(
+				"app memory of %dM exceeds the instance memory limit of %dM of space quota '%s'",
+				memory.(int), quota.InstanceMemoryLimit, quota.Name)
+		}
+		if quota.MemoryLimit >= 0 && requested > quota.MemoryLimit {
+			return fmt.Errorf(
+				"requested memory of %dM (%dM x %d instances) exceeds the total memory limit of %dM of space quota '%s'",
+				requested, memory.(int), instances, quota.MemoryLimit, quota.Name)
+		}
+	}
+
+	if len(org.QuotaGUID) > 0 {
+		quota, err := qm.ReadQuota(cfapi.OrgQuota, org.QuotaGUID)
+		if err != nil {
+			return err
+		}
+		if quota.InstanceMemoryLimit >= 0 && int64(memory.(int)) > quota.InstanceMemoryLimit {
+			return fmt.Errorf(
+				"app memory of %dM exceeds the instance memory limit of %dM of org quota '%s'",
+				memory.(int), quota.InstanceMemoryLimit, quota.Name)
+		}
+		if quota.MemoryLimit >= 0 {
+			usage, err := qm.GetOrgMemoryUsage(org.ID)
+			if err != nil {
+				session.Log.DebugMessage("Skipping org memory usage check for org %s: %s", org.ID, err.Error())
+				return nil
+			}
+			available := quota.MemoryLimit - usage
+			if len(diff.Id()) > 0 && len(diff.Get("blue_green").([]interface{})) == 0 {
+				// an in-place update releases the memory held by the
+				// current allocation; during a blue/green update both
+				// versions run concurrently so nothing is released
+				oldMemory, _ := diff.GetChange("memory")
+				oldInstances, _ := diff.GetChange("instances")
+				available += int64(oldMemory.(int) * oldInstances.(int))
+			}
+			if requested > available {
+				return fmt.Errorf(
+					"requested memory of %dM (%dM x %d instances) exceeds the remaining %dM of org quota '%s' (limit %dM, in use %dM)",
+					requested, memory.(int), instances, available, quota.Name, quota.MemoryLimit, usage)
+			}
+		}
+	}
+	return nil
+}
+
+// runPostStartProbe - probes each route mapped to the app over HTTP after it
+// was started, failing the apply when the app never becomes externally
+// reachable; this complements the platform health check, which only tests
+// the container
+func runPostStartProbe(d *schema.ResourceData, session *cfapi.Session, appID string) (err error) {
+
+	v, ok := d.GetOk("post_start_probe")
+	if !ok || len(v.([]interface{})) == 0 {
+		return nil
+	}
+	probe := v.([]interface{})[0].(map[string]interface{})
+	urlTemplate := probe["url"].(string)
+	expectedStatus := probe["expected_status"].(int)
+	retries := probe["retries"].(int)
+	interval := time.Second * time.Duration(probe["interval"].(int))
+
+	endpoints, err := appRouteEndpoints(session, appID)
+	if err != nil {
+		return err
+	}
+	if len(endpoints) == 0 {
+		session.Log.DebugMessage("post_start_probe skipped as app %s has no mapped routes", appID)
+		return nil
+	}
+
+	for _, endpoint := range endpoints {
+		url := strings.Replace(urlTemplate, "{route}", endpoint, -1)
+		if err = probeAppURL(session, url, expectedStatus, retries, interval); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// probeAppURL - polls the given URL until it answers with the expected
+// status or the attempts are exhausted
+func probeAppURL(session *cfapi.Session, url string, expectedStatus, retries int, interval time.Duration) error {
+
+	var lastErr error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(interval)
+		}
+		response, err := session.HTTPClient().Get(url)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		response.Body.Close()
+		if response.StatusCode == expectedStatus {
+			session.Log.DebugMessage("post_start_probe of '%s' succeeded with status %d", url, response.StatusCode)
+			return nil
+		}
+		lastErr = fmt.Errorf("unexpected status %d", response.StatusCode)
+	}
+	return fmt.Errorf(
+		"post_start_probe of '%s' did not return status %d after %d attempts: %s",
+		url, expectedStatus, retries+1, lastErr)
+}
+
+// appRouteEndpoints - resolves the external endpoints of all routes mapped
+// to the given app
+func appRouteEndpoints(session *cfapi.Session, appID string) (endpoints []string, err error) {
+
+	rm := session.RouteManager()
+	dm := session.DomainManager()
+
+	mappings, err := rm.ReadRouteMappingsByApp(appID)
+	if err != nil {
+		return nil, err
+	}
+	for _, mapping := range mappings {
+		route, err := rm.ReadRoute(mapping["route"].(string))
+		if err != nil {
+			return nil, err
+		}
+		domain, err := dm.FindDomain(route.DomainGUID)
+		if err != nil {
+			return nil, err
+		}
+
+		endpoint := domain.Name
+		if route.Hostname != nil && len(*route.Hostname) > 0 {
+			endpoint = *route.Hostname + "." + domain.Name
+		}
+		if route.Port != nil && *route.Port > 0 {
+			endpoint = fmt.Sprintf("%s:%d", endpoint, *route.Port)
+		}
+		if route.Path != nil && len(*route.Path) > 0 {
+			endpoint = endpoint + *route.Path
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints, nil
+}
+
+// waitForPromotionGate - holds a blue/green promotion until the optional
+// approval gate is satisfied: with only 'pause_before_promotion' set the
+// candidate stays on its staging routes for the given number of seconds,
+// and with 'promotion_gate_script' set the script is polled until it exits
+// 0, enabling manual smoke tests within a single apply
+func waitForPromotionGate(session *cfapi.Session, config map[string]interface{}, candidate cfapi.CCApp, timeout time.Duration) error {
+
+	pause := 0
+	if v, ok := config["pause_before_promotion"]; ok {
+		pause = v.(int)
+	}
+	script := ""
+	if v, ok := config["promotion_gate_script"]; ok {
+		script = v.(string)
+	}
+
+	if len(script) == 0 {
+		if pause > 0 {
+			session.Log.UI.Say("Pausing %d seconds before promoting %s...", pause, terminal.EntityNameColor(candidate.Name))
+			time.Sleep(time.Duration(pause) * time.Second)
+		}
+		return nil
+	}
+
+	// with a gate script 'pause_before_promotion' acts as the gate timeout
+	if pause > 0 {
+		timeout = time.Duration(pause) * time.Second
+	}
+	session.Log.UI.Say("Waiting for promotion gate of %s...", terminal.EntityNameColor(candidate.Name))
+
+	deadline := time.Now().Add(timeout)
+	for {
+		cmd := shellCommand(script)
+		cmd.Env = append(os.Environ(),
+			"CF_APP_NAME="+candidate.Name,
+			"CF_APP_GUID="+candidate.ID)
+
+		output, err := cmd.CombinedOutput()
+		if err == nil {
+			return nil
+		}
+		session.Log.DebugMessage("promotion gate for app %s not passed yet: %s\n%s", candidate.Name, err.Error(), string(output))
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf(
+				"promotion gate script for app %s did not pass within %s; the candidate app was not promoted",
+				candidate.Name, timeout)
+		}
+		time.Sleep(10 * time.Second)
+	}
+}
+
+// checkMetricGate - when a 'metric_gate' is configured on the blue_green
+// block, queries the metrics endpoint and fails when the value at the JSON
+// path exceeds the threshold, so a canary showing elevated error rates is
+// never scaled further
+func checkMetricGate(session *cfapi.Session, config map[string]interface{}, candidate cfapi.CCApp) error {
+
+	gates, ok := config["metric_gate"].([]interface{})
+	if !ok || len(gates) == 0 {
+		return nil
+	}
+	gate := gates[0].(map[string]interface{})
+
+	if settle := gate["settle_wait"].(int); settle > 0 {
+		time.Sleep(time.Duration(settle) * time.Second)
+	}
+
+	metricsURL := gate["url"].(string)
+	response, err := session.HTTPClient().Get(metricsURL)
+	if err != nil {
+		return fmt.Errorf("metric gate for app %s: unable to query '%s': %s", candidate.Name, metricsURL, err.Error())
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("metric gate for app %s: unable to read response from '%s': %s", candidate.Name, metricsURL, err.Error())
+	}
+
+	var document interface{}
+	if err = json.Unmarshal(body, &document); err != nil {
+		return fmt.Errorf("metric gate for app %s: response from '%s' is not valid JSON: %s", candidate.Name, metricsURL, err.Error())
+	}
+	value, err := evaluateMetricPath(document, gate["json_path"].(string))
+	if err != nil {
+		return fmt.Errorf("metric gate for app %s: %s", candidate.Name, err.Error())
+	}
+
+	threshold := gate["threshold"].(float64)
+	if value > threshold {
+		return fmt.Errorf(
+			"metric gate for app %s failed: '%s' is %g which exceeds the threshold of %g; "+
+				"the rollout was halted with the venerable app still serving",
+			candidate.Name, gate["json_path"].(string), value, threshold)
+	}
+	session.Log.DebugMessage("Metric gate for app %s passed: %g <= %g", candidate.Name, value, threshold)
+	return nil
+}
+
+// evaluateMetricPath - resolves a dotted path with optional numeric indexes
+// (e.g. 'data.rates[0].errors') in an unmarshalled JSON document and returns
+// the value at it as a float
+func evaluateMetricPath(document interface{}, path string) (float64, error) {
+
+	current := document
+	for _, segment := range strings.Split(path, ".") {
+
+		parts := strings.Split(segment, "[")
+		key := parts[0]
+
+		if len(key) > 0 {
+			object, ok := current.(map[string]interface{})
+			if !ok {
+				return 0, fmt.Errorf("'%s' does not lead to an object at '%s'", path, key)
+			}
+			if current, ok = object[key]; !ok {
+				return 0, fmt.Errorf("'%s' not found in the metrics response", path)
+			}
+		}
+		for _, part := range parts[1:] {
+			index, err := strconv.Atoi(strings.TrimSuffix(part, "]"))
+			if err != nil {
+				return 0, fmt.Errorf("invalid index '[%s' in metric path '%s'", part, path)
+			}
+			array, ok := current.([]interface{})
+			if !ok || index < 0 || index >= len(array) {
+				return 0, fmt.Errorf("index %d in metric path '%s' is out of range", index, path)
+			}
+			current = array[index]
+		}
+	}
+
+	value, ok := current.(float64)
+	if !ok {
+		return 0, fmt.Errorf("value at '%s' is not a number", path)
+	}
+	return value, nil
+}
+
+// checkAppMaintenanceWindow - when a 'maintenance_window' is configured,
+// updates that restart or restage the application are only executed within
+// the window (evaluated in UTC); outside of it the apply either fails with
+// the next opening or waits for it, depending on 'on_violation'
+func checkAppMaintenanceWindow(d *schema.ResourceData, session *cfapi.Session) error {
+
+	v, ok := d.GetOk("maintenance_window")
+	if !ok || len(v.([]interface{})) == 0 {
+		return nil
+	}
+	window := v.([]interface{})[0].(map[string]interface{})
+
+	start, _ := time.Parse("15:04", window["start"].(string))
+	end, _ := time.Parse("15:04", window["end"].(string))
+	startOffset := time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute
+	duration := end.Sub(start)
+	if duration <= 0 {
+		// the window spans midnight
+		duration += 24 * time.Hour
+	}
+
+	days := make(map[time.Weekday]bool)
+	for _, v := range window["days"].([]interface{}) {
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if day.String() == v.(string) {
+				days[day] = true
+			}
+		}
+	}
+
+	now := time.Now().UTC()
+	nextOpen := appMaintenanceWindowNextOpen(now, startOffset, duration, days)
+	if nextOpen.IsZero() {
+		return nil
+	}
+
+	if window["on_violation"].(string) == "wait" {
+		// never block past the resource timeout; a window opening later
+		// than that fails up front with the next opening instead
+		if nextOpen.Sub(now) > appTimeout(d, session) {
+			return fmt.Errorf(
+				"the next maintenance window of app '%s' opens %s, which is beyond the configured timeout of %s; "+
+					"the update was not applied",
+				d.Get("name").(string), nextOpen.Format(time.RFC1123), appTimeout(d, session))
+		}
+		session.Log.UI.Say("Waiting until %s for the maintenance window to apply the update...",
+			terminal.EntityNameColor(nextOpen.Format(time.RFC1123)))
+		// sleep in short increments so the wait ends promptly when the
+		// window opens and the process stays responsive to interruption
+		for {
+			remaining := time.Until(nextOpen)
+			if remaining <= 0 {
+				return nil
+			}
+			if remaining > 30*time.Second {
+				remaining = 30 * time.Second
+			}
+			time.Sleep(remaining)
+		}
+	}
+	return fmt.Errorf(
+		"the update requires a restart or restage of app '%s' and the current time is outside the configured "+
+			"maintenance window; the next window opens %s, or set 'on_violation' to \"wait\"",
+		d.Get("name").(string), nextOpen.Format(time.RFC1123))
+}
+
+// appMaintenanceWindowNextOpen - returns the zero time when t lies within
+// the window, otherwise the start of the next window occurrence
+func appMaintenanceWindowNextOpen(t time.Time, startOffset, duration time.Duration, days map[time.Weekday]bool) time.Time {
+
+	var nextOpen time.Time
+	// check the previous day as well, as a window spanning midnight may
+	// still be open from the day before
+	for offset := -1; offset <= 7; offset++ {
+		day := t.AddDate(0, 0, offset)
+		if len(days) > 0 && !days[day.Weekday()] {
+			continue
+		}
+		windowStart := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, t.Location()).Add(startOffset)
+		if !t.Before(windowStart) && t.Before(windowStart.Add(duration)) {
+			return time.Time{}
+		}
+		if windowStart.After(t) && (nextOpen.IsZero() || windowStart.Before(nextOpen)) {
+			nextOpen = windowStart
+		}
+	}
+	return nextOpen
+}
+
+// validateAppSSHDiff - asserts at plan time that 'enable_ssh' does not
+// conflict with the space or platform level SSH settings, a mismatch that
+// otherwise only surfaces as confusing connection failures at runtime; opt-in
+// via 'validate_ssh' so existing configs keep planning on platforms that do
+// not advertise an SSH endpoint
+func validateAppSSHDiff(diff *schema.ResourceDiff, session *cfapi.Session) error {
+
+	if !diff.Get("validate_ssh").(bool) {
+		return nil
+	}
+	v, ok := diff.GetOk("enable_ssh")
+	if !ok || !v.(bool) {
+		return nil
+	}
+
+	if len(session.Info().AppSSHEndpoint) == 0 {
+		return fmt.Errorf(
+			"app '%s' sets 'enable_ssh' but SSH is disabled on the platform (no app SSH endpoint is advertised)",
+			diff.Get("name").(string))
+	}
+
+	spaceID := diff.Get("space").(string)
+	if len(spaceID) == 0 {
+		return nil
+	}
+	space, err := session.SpaceManager().ReadSpace(spaceID)
+	if err != nil {
+		session.Log.DebugMessage("Skipping SSH check, unable to read space %s: %s", spaceID, err.Error())
+		return nil
+	}
+	if !space.AllowSSH {
+		return fmt.Errorf(
+			"app '%s' sets 'enable_ssh' but SSH is disabled for space '%s'; enable it on the space first (e.g. via cloudfoundry_space_feature)",
+			diff.Get("name").(string), space.Name)
+	}
+	return nil
+}
+
+// setAppOperationSummary - records a structured summary of the finished
+// create or update in the computed 'last_operation_summary' map so pipelines
+// can pick up deployment metrics without parsing logs
+func setAppOperationSummary(d *schema.ResourceData, session *cfapi.Session, operation string, started time.Time, uploadSize int64) {
+
+	// report what was actually observed on the platform, not what the
+	// configuration asked for
+	instances := 0
+	routes := 0
+	if app, err := session.AppManager().ReadApp(d.Id()); err != nil {
+		session.Log.DebugMessage("Apply summary: unable to read app %s: %s", d.Id(), err.Error())
+	} else if app.State != nil && *app.State == cfapi.AppStarted {
+		if running, _, err := session.AppManager().CountAppInstanceStates(app); err != nil {
+			session.Log.DebugMessage("Apply summary: unable to count instances of app %s: %s", d.Id(), err.Error())
+		} else {
+			instances = running
+		}
+	}
+	if mappings, err := session.RouteManager().ReadRouteMappingsByApp(d.Id()); err != nil {
+		session.Log.DebugMessage("Apply summary: unable to read route mappings of app %s: %s", d.Id(), err.Error())
+	} else {
+		routes = len(mappings)
+	}
+
+	summary := map[string]interface{}{
+		"operation":         operation,
+		"duration":          time.Since(started).Round(time.Second).String(),
+		"upload_size_bytes": strconv.FormatInt(uploadSize, 10),
+		"instances_started": strconv.Itoa(instances),
+		"routes_mapped":     strconv.Itoa(routes),
+		"finished_at":       time.Now().UTC().Format(time.RFC3339),
+	}
+	d.Set("last_operation_summary", summary)
+	session.Log.DebugMessage("Apply summary for application: %# v", summary)
+}
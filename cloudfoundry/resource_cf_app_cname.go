@@ -0,0 +1,213 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func resourceAppCname() *schema.Resource {
+
+	return &schema.Resource{
+
+		Create: resourceAppCnameCreate,
+		Read:   resourceAppCnameRead,
+		Delete: resourceAppCnameDelete,
+
+		Schema: map[string]*schema.Schema{
+
+			"app": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"fqdn": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"hostname": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"domain": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"mapping_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cname_target": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAppCnameCreate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	am := session.AppManager()
+	rm := session.RouteManager()
+
+	appID := d.Get("app").(string)
+	fqdn := d.Get("fqdn").(string)
+
+	app, err := am.ReadApp(appID)
+	if err != nil {
+		return err
+	}
+
+	domain, hostname, shared, sharedDomains, err := appCnameMatchDomain(session, fqdn)
+	if err != nil {
+		return err
+	}
+
+	route, err := rm.FindRouteInDomain(domain.ID, hostname)
+	if err != nil {
+		if !strings.Contains(err.Error(), "not found") {
+			return err
+		}
+		newRoute := cfapi.CCRoute{
+			DomainGUID: domain.ID,
+			SpaceGUID:  app.SpaceGUID,
+		}
+		if len(hostname) > 0 {
+			newRoute.Hostname = &hostname
+		}
+		if route, err = rm.CreateRoute(newRoute, false); err != nil {
+			return err
+		}
+	}
+
+	mappingID, err := rm.CreateRouteMapping(route.ID, appID, nil)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(route.ID)
+	d.Set("hostname", hostname)
+	d.Set("domain", domain.Name)
+	d.Set("mapping_id", mappingID)
+	d.Set("cname_target", appCnameTarget(hostname, shared, sharedDomains))
+	return nil
+}
+
+func resourceAppCnameRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	rm := session.RouteManager()
+
+	route, err := rm.ReadRoute(d.Id())
+	if err != nil {
+		if strings.Contains(err.Error(), "status code: 404") {
+			d.SetId("")
+			err = nil
+		}
+		return err
+	}
+
+	if route.Hostname != nil {
+		d.Set("hostname", *route.Hostname)
+	}
+
+	// the mapping to the app may have been removed out of band
+	mappings, err := rm.ReadRouteMappingsByRoute(route.ID)
+	if err != nil {
+		return err
+	}
+	appID := d.Get("app").(string)
+	mappingID := ""
+	for _, mapping := range mappings {
+		if mapping["app"] == appID {
+			mappingID = mapping["mapping_id"].(string)
+			break
+		}
+	}
+	d.Set("mapping_id", mappingID)
+
+	return nil
+}
+
+func resourceAppCnameDelete(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	rm := session.RouteManager()
+
+	if mappingID := d.Get("mapping_id").(string); len(mappingID) > 0 {
+		if err = rm.DeleteRouteMapping(mappingID); err != nil && !strings.Contains(err.Error(), "status code: 404") {
+			return err
+		}
+	}
+	if err = rm.DeleteRoute(d.Id()); err != nil && !strings.Contains(err.Error(), "status code: 404") {
+		return err
+	}
+	return nil
+}
+
+// appCnameMatchDomain - finds the longest shared or private domain matching
+// the FQDN and splits off the route hostname
+func appCnameMatchDomain(session *cfapi.Session, fqdn string) (
+	matched cfapi.CCDomain, hostname string, shared bool, sharedDomains []cfapi.CCDomain, err error) {
+
+	dm := session.DomainManager()
+
+	sharedDomains, err = dm.GetSharedDomains()
+	if err != nil {
+		return cfapi.CCDomain{}, "", false, nil, err
+	}
+	privateDomains, err := dm.GetPrivateDomains()
+	if err != nil {
+		return cfapi.CCDomain{}, "", false, nil, err
+	}
+
+	match := func(domains []cfapi.CCDomain, isShared bool) {
+		for _, domain := range domains {
+			if fqdn != domain.Name && !strings.HasSuffix(fqdn, "."+domain.Name) {
+				continue
+			}
+			if len(domain.Name) > len(matched.Name) {
+				matched = domain
+				shared = isShared
+			}
+		}
+	}
+	match(sharedDomains, true)
+	match(privateDomains, false)
+
+	if len(matched.ID) == 0 {
+		return cfapi.CCDomain{}, "", false, nil,
+			fmt.Errorf("no shared or private domain matches '%s'; create a cloudfoundry_domain for it first", fqdn)
+	}
+	hostname = strings.TrimSuffix(strings.TrimSuffix(fqdn, matched.Name), ".")
+	return matched, hostname, shared, sharedDomains, nil
+}
+
+// appCnameTarget - the DNS name the FQDN should be aliased to; empty when the
+// route lives on a shared domain whose DNS already points at the platform router
+func appCnameTarget(hostname string, shared bool, sharedDomains []cfapi.CCDomain) string {
+
+	if shared || len(sharedDomains) == 0 {
+		return ""
+	}
+	if len(hostname) > 0 {
+		return hostname + "." + sharedDomains[0].Name
+	}
+	return sharedDomains[0].Name
+}
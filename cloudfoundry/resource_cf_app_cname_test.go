@@ -0,0 +1,127 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"testing"
+
+	"code.cloudfoundry.org/cli/cf/errors"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+const appCnameResource = `
+
+data "cloudfoundry_org" "org" {
+    name = "pcfdev-org"
+}
+data "cloudfoundry_space" "space" {
+    name = "pcfdev-space"
+	org = "${data.cloudfoundry_org.org.id}"
+}
+
+resource "cloudfoundry_domain" "cname" {
+	sub_domain = "cname"
+	domain = "%[1]s"
+	org = "${data.cloudfoundry_org.org.id}"
+}
+
+resource "cloudfoundry_app" "test-app" {
+	name = "test-app-cname"
+	space = "${data.cloudfoundry_space.space.id}"
+	memory = "512"
+	disk_quota = "512"
+	timeout = 1800
+	url = "file://../tests/cf-acceptance-tests/assets/java-spring/java-spring.jar"
+}
+
+resource "cloudfoundry_app_cname" "www" {
+	depends_on = [ "cloudfoundry_domain.cname" ]
+	app = "${cloudfoundry_app.test-app.id}"
+	fqdn = "www.cname.%[1]s"
+}
+`
+
+func TestAccAppCname_normal(t *testing.T) {
+
+	ref := "cloudfoundry_app_cname.www"
+
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckAppCnameDestroyed("www", "cname."+defaultAppDomain()),
+			Steps: []resource.TestStep{
+
+				resource.TestStep{
+					Config: fmt.Sprintf(appCnameResource, defaultAppDomain()),
+					Check: resource.ComposeTestCheckFunc(
+						checkAppCnameExists(ref),
+						resource.TestCheckResourceAttr(
+							ref, "hostname", "www"),
+						resource.TestCheckResourceAttr(
+							ref, "domain", "cname."+defaultAppDomain()),
+					),
+				},
+			},
+		})
+}
+
+func checkAppCnameExists(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) (err error) {
+
+		session := testAccProvider.Meta().(*cfapi.Session)
+
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("app cname '%s' not found in terraform state", resource)
+		}
+
+		session.Log.DebugMessage(
+			"terraform state for resource '%s': %# v",
+			resource, rs)
+
+		id := rs.Primary.ID
+		attributes := rs.Primary.Attributes
+
+		rm := session.RouteManager()
+		route, err := rm.ReadRoute(id)
+		if err != nil {
+			return err
+		}
+		if route.Hostname != nil {
+			if err = assertEquals(attributes, "hostname", *route.Hostname); err != nil {
+				return err
+			}
+		}
+
+		mappings, err := rm.ReadRouteMappingsByRoute(id)
+		if err != nil {
+			return err
+		}
+		for _, mapping := range mappings {
+			if mapping["app"] == attributes["app"] {
+				return assertEquals(attributes, "mapping_id", mapping["mapping_id"])
+			}
+		}
+		return fmt.Errorf("cname route '%s' is not mapped to app '%s'", id, attributes["app"])
+	}
+}
+
+func testAccCheckAppCnameDestroyed(hostname string, domain string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+
+		session := testAccProvider.Meta().(*cfapi.Session)
+		if _, err := session.RouteManager().FindRoute(domain, &hostname, nil, nil); err != nil {
+			switch err.(type) {
+			case *errors.ModelNotFoundError:
+				return nil
+			default:
+				return err
+			}
+		}
+		return fmt.Errorf("cname route with hostname '%s' and domain '%s' still exists in cloud foundry", hostname, domain)
+	}
+}
@@ -0,0 +1,131 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func resourceAppCopyBits() *schema.Resource {
+
+	return &schema.Resource{
+
+		Create: resourceAppCopyBitsCreate,
+		Read:   resourceAppCopyBitsRead,
+		Delete: resourceAppCopyBitsDelete,
+
+		Schema: map[string]*schema.Schema{
+
+			"source_app": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"space": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"stopped": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"timeout": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Default:  DefaultAppTimeout,
+			},
+		},
+	}
+}
+
+func resourceAppCopyBitsCreate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	am := session.AppManager()
+
+	timeout := time.Second * time.Duration(d.Get("timeout").(int))
+
+	sourceApp, err := am.ReadApp(d.Get("source_app").(string))
+	if err != nil {
+		return err
+	}
+
+	// create the target app with the source app's configuration so the copied
+	// bits stage and run the same way in the new space
+	app, err := am.CreateApp(cfapi.CCApp{
+		Name:                    d.Get("name").(string),
+		SpaceGUID:               d.Get("space").(string),
+		Instances:               sourceApp.Instances,
+		Memory:                  sourceApp.Memory,
+		DiskQuota:               sourceApp.DiskQuota,
+		StackGUID:               sourceApp.StackGUID,
+		Buildpack:               sourceApp.Buildpack,
+		Command:                 sourceApp.Command,
+		EnableSSH:               sourceApp.EnableSSH,
+		HealthCheckHTTPEndpoint: sourceApp.HealthCheckHTTPEndpoint,
+		HealthCheckType:         sourceApp.HealthCheckType,
+		HealthCheckTimeout:      sourceApp.HealthCheckTimeout,
+		Environment:             sourceApp.Environment,
+	})
+	if err != nil {
+		return err
+	}
+	d.SetId(app.ID)
+
+	if err = am.CopyBits(sourceApp.ID, app.ID, timeout); err != nil {
+		return err
+	}
+
+	if !d.Get("stopped").(bool) {
+		if err = am.StartApp(app.ID, timeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceAppCopyBitsRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	app, err := session.AppManager().ReadApp(d.Id())
+	if err != nil {
+		if strings.Contains(err.Error(), "status code: 404") {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	d.Set("name", app.Name)
+	d.Set("space", app.SpaceGUID)
+	return nil
+}
+
+func resourceAppCopyBitsDelete(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	return session.AppManager().DeleteApp(d.Id(), true)
+}
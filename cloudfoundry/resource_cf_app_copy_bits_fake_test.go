@@ -0,0 +1,90 @@
+package cloudfoundry
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestAppCopyBits_fake(t *testing.T) {
+
+	f, session := newFakeSession(t)
+	defer f.Close()
+
+	f.Stub("GET", "/v2/apps/app-src", 200, map[string]interface{}{
+		"metadata": map[string]interface{}{"guid": "app-src"},
+		"entity": map[string]interface{}{
+			"name":       "original",
+			"space_guid": "space-src",
+			"instances":  2,
+			"memory":     512,
+			"disk_quota": 1024,
+		},
+	})
+	f.Stub("POST", "/v2/apps", 201, map[string]interface{}{
+		"metadata": map[string]interface{}{"guid": "app-dst"},
+		"entity": map[string]interface{}{
+			"name":       "copy",
+			"space_guid": "space-dst",
+		},
+	})
+	f.Stub("POST", "/v2/apps/app-dst/copy_bits", 201, map[string]interface{}{
+		"metadata": map[string]interface{}{"guid": "job-one"},
+		"entity":   map[string]interface{}{"status": "finished"},
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceAppCopyBits().Schema, map[string]interface{}{
+		"source_app": "app-src",
+		"space":      "space-dst",
+		"name":       "copy",
+		"stopped":    true,
+	})
+	if err := resourceAppCopyBitsCreate(d, session); err != nil {
+		t.Fatalf("create returned error: %s", err)
+	}
+	if d.Id() != "app-dst" {
+		t.Errorf("expected the new app GUID as ID, got '%s'", d.Id())
+	}
+
+	copied := false
+	for _, r := range f.Requests() {
+		if r == "POST /v2/apps/app-dst/copy_bits" {
+			copied = true
+		}
+	}
+	if !copied {
+		t.Error("expected the source app's bits to be copied to the new app")
+	}
+
+	f.Stub("GET", "/v2/apps/app-dst", 200, map[string]interface{}{
+		"metadata": map[string]interface{}{"guid": "app-dst"},
+		"entity": map[string]interface{}{
+			"name":       "copy",
+			"space_guid": "space-dst",
+		},
+	})
+	if err := resourceAppCopyBitsRead(d, session); err != nil {
+		t.Fatalf("read returned error: %s", err)
+	}
+	if v := d.Get("space").(string); v != "space-dst" {
+		t.Errorf("expected the space to be read back, got '%s'", v)
+	}
+
+	f.Stub("GET", "/v2/service_bindings", 200, map[string]interface{}{
+		"next_url":  nil,
+		"resources": []interface{}{},
+	})
+	f.Stub("DELETE", "/v2/apps/app-dst", 204, nil)
+	if err := resourceAppCopyBitsDelete(d, session); err != nil {
+		t.Errorf("delete returned error: %s", err)
+	}
+
+	// an app deleted out of band clears the state
+	d.SetId("app-gone")
+	if err := resourceAppCopyBitsRead(d, session); err != nil {
+		t.Fatalf("read returned error: %s", err)
+	}
+	if len(d.Id()) != 0 {
+		t.Error("expected the ID to be cleared for a removed app")
+	}
+}
@@ -0,0 +1,189 @@
+package cloudfoundry
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+// newFakeSession - a session backed by an in-process fake Cloud Controller,
+// for exercising resource helpers without a real foundation
+func newFakeSession(t *testing.T) (*cfapi.FakeCloudController, *cfapi.Session) {
+
+	f := cfapi.NewFakeCloudController()
+	session, err := cfapi.NewFakeSession(f)
+	if err != nil {
+		f.Close()
+		t.Fatalf("unable to create fake session: %s", err)
+	}
+	return f, session
+}
+
+func TestAppRouteMappings_fake(t *testing.T) {
+
+	f, session := newFakeSession(t)
+	defer f.Close()
+	rm := session.RouteManager()
+
+	mappingResource := map[string]interface{}{
+		"metadata": map[string]interface{}{"guid": "mapping-one"},
+		"entity": map[string]interface{}{
+			"app_guid":   "app-one",
+			"route_guid": "route-one",
+			"app_port":   8080,
+		},
+	}
+	f.Stub("POST", "/v2/route_mappings", 201, mappingResource)
+	f.Stub("GET", "/v2/route_mappings/mapping-one", 200, mappingResource)
+	f.Stub("GET", "/v2/route_mappings", 200, map[string]interface{}{
+		"next_url":  nil,
+		"resources": []interface{}{},
+	})
+
+	routes := []interface{}{
+		map[string]interface{}{"route": "route-one", "port": 0, "mapping_id": ""},
+	}
+	mapped, err := addRouteMappings("app-one", routes, "", rm)
+	if err != nil {
+		t.Fatalf("addRouteMappings returned error: %s", err)
+	}
+	if len(mapped) != 1 {
+		t.Fatalf("expected 1 mapped route, got %d", len(mapped))
+	}
+	if v := mapped[0].(map[string]interface{})["mapping_id"]; v != "mapping-one" {
+		t.Errorf("expected mapping_id 'mapping-one', got '%v'", v)
+	}
+	if v := mapped[0].(map[string]interface{})["port"]; v != 8080 {
+		t.Errorf("expected the created mapping's app_port 8080 to be read back, got '%v'", v)
+	}
+
+	// a route already mapped to another app must be rejected
+	f.Stub("GET", "/v2/route_mappings", 200, map[string]interface{}{
+		"next_url": nil,
+		"resources": []interface{}{
+			map[string]interface{}{
+				"metadata": map[string]interface{}{"guid": "mapping-other"},
+				"entity":   map[string]interface{}{"app_guid": "app-other", "route_guid": "route-one"},
+			},
+		},
+	})
+	if _, err = addRouteMappings("app-one", routes, "", rm); err == nil {
+		t.Error("expected an error mapping a route already mapped to another app")
+	} else if !strings.Contains(err.Error(), "already mapped") {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// deleting a mapping that is already gone is not an error
+	err = deleteRouteMappings([]interface{}{
+		map[string]interface{}{"route": "route-one", "mapping_id": "mapping-gone"},
+	}, rm)
+	if err != nil {
+		t.Errorf("expected a 404 on delete to be tolerated, got: %s", err)
+	}
+}
+
+func TestAppServiceBindings_fake(t *testing.T) {
+
+	f, session := newFakeSession(t)
+	defer f.Close()
+
+	// the instance is not found on the managed service instance endpoint,
+	// so it is treated as user-provided and bound via V3
+	f.Stub("POST", "/v3/service_credential_bindings", 201, map[string]interface{}{
+		"guid": "binding-one",
+	})
+	f.Stub("GET", "/v3/service_credential_bindings/binding-one/details", 200, map[string]interface{}{
+		"credentials": map[string]interface{}{"uri": "postgres://example.com/db"},
+	})
+
+	bindings, err := addServiceBindings("app-one",
+		[]map[string]interface{}{{"service_instance": "si-one"}}, session, false)
+	if err != nil {
+		t.Fatalf("addServiceBindings returned error: %s", err)
+	}
+	if len(bindings) != 1 {
+		t.Fatalf("expected 1 binding, got %d", len(bindings))
+	}
+	if v := bindings[0]["binding_id"]; v != "binding-one" {
+		t.Errorf("expected binding_id 'binding-one', got '%v'", v)
+	}
+	if v, ok := bindings[0]["credentials_hash"].(string); !ok || len(v) == 0 {
+		t.Error("expected credentials_hash to be set")
+	}
+	if _, ok := bindings[0]["credentials"]; ok {
+		t.Error("expected credentials to be kept out of state when not requested")
+	}
+
+	bindings, err = addServiceBindings("app-one",
+		[]map[string]interface{}{{"service_instance": "si-one"}}, session, true)
+	if err != nil {
+		t.Fatalf("addServiceBindings returned error: %s", err)
+	}
+	if _, ok := bindings[0]["credentials"]; !ok {
+		t.Error("expected credentials to be stored when requested")
+	}
+
+	// removing a binding that is already gone is not an error
+	err = removeServiceBindings([]map[string]interface{}{
+		{"service_instance": "si-one", "binding_id": "binding-gone"},
+	}, session.AppManager(), session.Log)
+	if err != nil {
+		t.Errorf("expected a 404 on delete to be tolerated, got: %s", err)
+	}
+}
+
+func TestAppBlueGreenGates_fake(t *testing.T) {
+
+	f, session := newFakeSession(t)
+	defer f.Close()
+
+	candidate := cfapi.CCApp{ID: "app-one", Name: "candidate"}
+
+	// without a gate script or pause the promotion gate opens immediately
+	err := waitForPromotionGate(session,
+		map[string]interface{}{"pause_before_promotion": 0, "promotion_gate_script": ""},
+		candidate, time.Minute)
+	if err != nil {
+		t.Errorf("expected an unconfigured promotion gate to pass, got: %s", err)
+	}
+
+	// a gate script that exits 0 opens the gate
+	err = waitForPromotionGate(session,
+		map[string]interface{}{"pause_before_promotion": 0, "promotion_gate_script": "true"},
+		candidate, time.Minute)
+	if err != nil {
+		t.Errorf("expected a passing promotion gate script to pass, got: %s", err)
+	}
+
+	f.Stub("GET", "/metrics", 200, map[string]interface{}{
+		"data": map[string]interface{}{
+			"rates": []interface{}{
+				map[string]interface{}{"errors": 2.0},
+			},
+		},
+	})
+	metricGate := func(threshold float64) map[string]interface{} {
+		return map[string]interface{}{
+			"metric_gate": []interface{}{
+				map[string]interface{}{
+					"url":         f.URL() + "/metrics",
+					"json_path":   "data.rates[0].errors",
+					"threshold":   threshold,
+					"settle_wait": 0,
+				},
+			},
+		}
+	}
+
+	if err = checkMetricGate(session, metricGate(5.0), candidate); err != nil {
+		t.Errorf("expected the metric gate to pass with 2 <= 5, got: %s", err)
+	}
+	err = checkMetricGate(session, metricGate(1.0), candidate)
+	if err == nil {
+		t.Error("expected the metric gate to fail with 2 > 1")
+	} else if !strings.Contains(err.Error(), "exceeds the threshold") {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
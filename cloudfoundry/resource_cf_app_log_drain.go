@@ -0,0 +1,229 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func resourceAppLogDrain() *schema.Resource {
+
+	return &schema.Resource{
+
+		Create: resourceAppLogDrainCreate,
+		Read:   resourceAppLogDrainRead,
+		Update: resourceAppLogDrainUpdate,
+		Delete: resourceAppLogDrainDelete,
+
+		Schema: map[string]*schema.Schema{
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"space": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"url": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"format": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "rfc5424",
+				ValidateFunc: validation.StringInSlice([]string{"rfc5424", "json"}, false),
+			},
+			"drain_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "logs",
+				ValidateFunc: validation.StringInSlice([]string{"logs", "metrics", "all"}, false),
+			},
+			"apps": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      resourceStringHash,
+			},
+			"drain_url": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAppLogDrainCreate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	sm := session.ServiceManager()
+
+	drainURL, err := appLogDrainURL(d)
+	if err != nil {
+		return err
+	}
+
+	id, err := sm.CreateUserProvidedService(
+		d.Get("name").(string), d.Get("space").(string), nil, drainURL, "")
+	if err != nil {
+		return err
+	}
+	d.SetId(id)
+	d.Set("drain_url", drainURL)
+
+	return appLogDrainBindApps(session, id, d.Get("apps").(*schema.Set).List())
+}
+
+func resourceAppLogDrainRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	sm := session.ServiceManager()
+
+	ups, err := sm.ReadUserProvidedService(d.Id())
+	if err != nil {
+		if strings.Contains(err.Error(), "status code: 404") {
+			d.SetId("")
+			err = nil
+		}
+		return err
+	}
+	d.Set("name", ups.Name)
+	d.Set("space", ups.SpaceGUID)
+	d.Set("drain_url", ups.SyslogDrainURL)
+
+	bindings, err := session.AppManager().ReadServiceBindingsByServiceInstance(d.Id())
+	if err != nil {
+		return err
+	}
+	apps := []interface{}{}
+	for _, binding := range bindings {
+		apps = append(apps, binding["app"])
+	}
+	d.Set("apps", schema.NewSet(resourceStringHash, apps))
+	return nil
+}
+
+func resourceAppLogDrainUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	sm := session.ServiceManager()
+
+	if d.HasChange("name") || d.HasChange("url") || d.HasChange("format") || d.HasChange("drain_type") {
+		drainURL, err := appLogDrainURL(d)
+		if err != nil {
+			return err
+		}
+		if _, err = sm.UpdateUserProvidedService(d.Id(), d.Get("name").(string), nil, drainURL, ""); err != nil {
+			return err
+		}
+		d.Set("drain_url", drainURL)
+	}
+
+	if d.HasChange("apps") {
+		old, new := d.GetChange("apps")
+		toRemove, toAdd := getListChanges(old, new)
+
+		am := session.AppManager()
+		bindings, err := am.ReadServiceBindingsByServiceInstance(d.Id())
+		if err != nil {
+			return err
+		}
+		for _, appID := range toRemove {
+			for _, binding := range bindings {
+				if binding["app"] == appID {
+					if err = am.DeleteServiceBinding(binding["binding_id"].(string)); err != nil {
+						return err
+					}
+					break
+				}
+			}
+		}
+		if err = appLogDrainBindApps(session, d.Id(), func() (l []interface{}) {
+			for _, a := range toAdd {
+				l = append(l, a)
+			}
+			return l
+		}()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceAppLogDrainDelete(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	am := session.AppManager()
+
+	bindings, err := am.ReadServiceBindingsByServiceInstance(d.Id())
+	if err != nil && !strings.Contains(err.Error(), "status code: 404") {
+		return err
+	}
+	for _, binding := range bindings {
+		if err = am.DeleteServiceBinding(binding["binding_id"].(string)); err != nil &&
+			!strings.Contains(err.Error(), "status code: 404") {
+			return err
+		}
+	}
+	if err = session.ServiceManager().DeleteUserProvidedService(d.Id()); err != nil &&
+		!strings.Contains(err.Error(), "status code: 404") {
+		return err
+	}
+	return nil
+}
+
+// appLogDrainURL - renders the syslog drain URL with the configured format
+// and drain type as query parameters
+func appLogDrainURL(d *schema.ResourceData) (string, error) {
+
+	drainURL, err := url.Parse(d.Get("url").(string))
+	if err != nil {
+		return "", fmt.Errorf("'url' is not a valid URL: %s", err.Error())
+	}
+
+	query := drainURL.Query()
+	if format := d.Get("format").(string); format != "rfc5424" {
+		query.Set("format", format)
+	}
+	if drainType := d.Get("drain_type").(string); drainType != "logs" {
+		query.Set("drain-type", drainType)
+	}
+	drainURL.RawQuery = query.Encode()
+	return drainURL.String(), nil
+}
+
+// appLogDrainBindApps - binds the drain service to each of the given apps
+func appLogDrainBindApps(session *cfapi.Session, serviceInstanceID string, apps []interface{}) (err error) {
+
+	am := session.AppManager()
+	for _, app := range apps {
+		if _, _, err = am.CreateServiceBinding(app.(string), serviceInstanceID, nil); err != nil {
+			return err
+		}
+		session.Log.DebugMessage("Bound log drain '%s' to app '%s'.", serviceInstanceID, app.(string))
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"testing"
+
+	"code.cloudfoundry.org/cli/cf/errors"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+const appLogDrainResourceCommon = `
+
+data "cloudfoundry_org" "org" {
+    name = "pcfdev-org"
+}
+data "cloudfoundry_space" "space" {
+    name = "pcfdev-space"
+	org = "${data.cloudfoundry_org.org.id}"
+}
+
+resource "cloudfoundry_app" "drained" {
+	name = "test-app-drained"
+	space = "${data.cloudfoundry_space.space.id}"
+	memory = "512"
+	disk_quota = "512"
+	timeout = 1800
+	url = "file://../tests/cf-acceptance-tests/assets/java-spring/java-spring.jar"
+}
+`
+
+const appLogDrainResource = appLogDrainResourceCommon + `
+
+resource "cloudfoundry_app_log_drain" "syslog" {
+	name = "test-log-drain"
+	space = "${data.cloudfoundry_space.space.id}"
+	url = "syslog://logs.example.com:514"
+	apps = [ "${cloudfoundry_app.drained.id}" ]
+}
+`
+
+const appLogDrainResourceUpdate = appLogDrainResourceCommon + `
+
+resource "cloudfoundry_app_log_drain" "syslog" {
+	name = "test-log-drain"
+	space = "${data.cloudfoundry_space.space.id}"
+	url = "syslog://logs.example.com:1514"
+	format = "json"
+	apps = [ "${cloudfoundry_app.drained.id}" ]
+}
+`
+
+func TestAccAppLogDrain_normal(t *testing.T) {
+
+	ref := "cloudfoundry_app_log_drain.syslog"
+
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckAppLogDrainDestroyed("test-log-drain", "data.cloudfoundry_space.space"),
+			Steps: []resource.TestStep{
+
+				resource.TestStep{
+					Config: appLogDrainResource,
+					Check: resource.ComposeTestCheckFunc(
+						checkAppLogDrainExists(ref),
+						resource.TestCheckResourceAttr(
+							ref, "name", "test-log-drain"),
+						resource.TestCheckResourceAttr(
+							ref, "drain_url", "syslog://logs.example.com:514"),
+						resource.TestCheckResourceAttr(
+							ref, "apps.#", "1"),
+					),
+				},
+				resource.TestStep{
+					Config: appLogDrainResourceUpdate,
+					Check: resource.ComposeTestCheckFunc(
+						checkAppLogDrainExists(ref),
+						resource.TestCheckResourceAttr(
+							ref, "drain_url", "syslog://logs.example.com:1514?format=json"),
+					),
+				},
+			},
+		})
+}
+
+func checkAppLogDrainExists(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) (err error) {
+
+		session := testAccProvider.Meta().(*cfapi.Session)
+
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("log drain '%s' not found in terraform state", resource)
+		}
+
+		session.Log.DebugMessage(
+			"terraform state for resource '%s': %# v",
+			resource, rs)
+
+		id := rs.Primary.ID
+		attributes := rs.Primary.Attributes
+
+		ups, err := session.ServiceManager().ReadUserProvidedService(id)
+		if err != nil {
+			return err
+		}
+		if err = assertEquals(attributes, "name", ups.Name); err != nil {
+			return err
+		}
+		return assertEquals(attributes, "drain_url", ups.SyslogDrainURL)
+	}
+}
+
+func testAccCheckAppLogDrainDestroyed(name string, spaceResource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+
+		session := testAccProvider.Meta().(*cfapi.Session)
+
+		rs, ok := s.RootModule().Resources[spaceResource]
+		if !ok {
+			return fmt.Errorf("space '%s' not found in terraform state", spaceResource)
+		}
+		if _, err := session.ServiceManager().FindServiceInstance(name, rs.Primary.ID); err != nil {
+			switch err.(type) {
+			case *errors.ModelNotFoundError:
+				return nil
+			default:
+				return err
+			}
+		}
+		return fmt.Errorf("log drain '%s' still exists in cloud foundry", name)
+	}
+}
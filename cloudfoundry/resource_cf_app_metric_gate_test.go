@@ -0,0 +1,67 @@
+package cloudfoundry
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEvaluateMetricPath(t *testing.T) {
+
+	document := `{
+		"errors": 3,
+		"rate": 0.25,
+		"data": {
+			"rates": [
+				{ "errors": 1.5 },
+				{ "errors": 7 }
+			],
+			"labels": [ "a", "b" ]
+		},
+		"series": [ [ 10, 20 ], [ 30 ] ],
+		"status": "ok"
+	}`
+
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(document), &parsed); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		value   float64
+		wantErr bool
+	}{
+		{name: "top level key", path: "errors", value: 3},
+		{name: "top level float", path: "rate", value: 0.25},
+		{name: "nested key with index", path: "data.rates[0].errors", value: 1.5},
+		{name: "second index", path: "data.rates[1].errors", value: 7},
+		{name: "nested arrays", path: "series[0][1]", value: 20},
+		{name: "missing key", path: "data.missing", wantErr: true},
+		{name: "index out of range", path: "data.rates[2].errors", wantErr: true},
+		{name: "negative index", path: "data.rates[-1].errors", wantErr: true},
+		{name: "invalid index", path: "data.rates[x].errors", wantErr: true},
+		{name: "index into object", path: "data[0]", wantErr: true},
+		{name: "key into array", path: "series.errors", wantErr: true},
+		{name: "value is not a number", path: "status", wantErr: true},
+		{name: "value is an object", path: "data", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := evaluateMetricPath(parsed, tc.path)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("evaluateMetricPath(%q) = %g, expected an error", tc.path, value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("evaluateMetricPath(%q) returned error: %s", tc.path, err)
+			}
+			if value != tc.value {
+				t.Errorf("evaluateMetricPath(%q) = %g, expected %g", tc.path, value, tc.value)
+			}
+		})
+	}
+}
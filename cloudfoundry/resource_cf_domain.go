@@ -46,6 +46,10 @@ func resourceDomain() *schema.Resource {
 				Optional:      true,
 				ConflictsWith: []string{"org"},
 			},
+			"router_group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"router_type": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -114,8 +118,15 @@ func resourceDomainCreate(d *schema.ResourceData, meta interface{}) error {
 		ccDomain, err = dm.CreatePrivateDomain(name, org.(string))
 	} else {
 		if routerGroupOk {
-			rg := routerGroup.(string)
-			ccDomain, err = dm.CreateSharedDomain(name, &rg)
+			rg, err := dm.FindRouterGroup(routerGroup.(string))
+			if err != nil {
+				return err
+			}
+			ccDomain, err = dm.CreateSharedDomain(name, &rg.GUID)
+			if err != nil {
+				return err
+			}
+			d.Set("router_group_id", ccDomain.RouterGroupGUID)
 			d.Set("router_type", ccDomain.RouterType)
 		} else {
 			ccDomain, err = dm.CreateSharedDomain(name, nil)
@@ -125,6 +136,7 @@ func resourceDomainCreate(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 	d.SetId(ccDomain.ID)
+	annotateManagedResource(session, "domains", ccDomain.ID)
 	return nil
 }
 
@@ -149,7 +161,7 @@ func resourceDomainRead(d *schema.ResourceData, meta interface{}) (err error) {
 		d.Set("name", ccDomain.Name)
 		d.Set("sub_domain", subDomain)
 		d.Set("domain", domain)
-		d.Set("route_group", ccDomain.RouterGroupGUID)
+		d.Set("router_group_id", ccDomain.RouterGroupGUID)
 		d.Set("router_type", ccDomain.RouterType)
 		return nil
 	}
@@ -180,6 +192,10 @@ func resourceDomainDelete(d *schema.ResourceData, meta interface{}) (err error)
 	dm := session.DomainManager()
 	id := d.Id()
 
+	if err = validateManagedResource(session, "domains", id); err != nil {
+		return err
+	}
+
 	if _, orgOk := d.GetOk("org"); orgOk {
 		return dm.DeletePrivateDomain(id)
 	}
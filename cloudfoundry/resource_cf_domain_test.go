@@ -21,14 +21,10 @@ resource "cloudfoundry_domain" "shared" {
 
 const domainResourceSharedTCP = `
 
-data "cloudfoundry_router_group" "tcp" {
-    name = "default-tcp"
-}
-
 resource "cloudfoundry_domain" "shared-tcp" {
     sub_domain = "tcp-test"
 	domain = "%s"
-	router_group = "${data.cloudfoundry_router_group.tcp.id}"
+	router_group = "default-tcp"
 }
 `
 
@@ -92,6 +88,8 @@ func TestAccSharedTCPDomain_normal(t *testing.T) {
 							ref, "domain", defaultAppDomain()),
 						resource.TestCheckResourceAttr(
 							ref, "router_type", "tcp"),
+						resource.TestCheckResourceAttrSet(
+							ref, "router_group_id"),
 					),
 				},
 			},
@@ -0,0 +1,119 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func resourceDropletCopy() *schema.Resource {
+
+	return &schema.Resource{
+
+		Create: resourceDropletCopyCreate,
+		Read:   resourceDropletCopyRead,
+		Delete: resourceDropletCopyDelete,
+
+		Schema: map[string]*schema.Schema{
+
+			"source_app": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"destination_app": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"restart": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"timeout": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Default:  DefaultAppTimeout,
+			},
+			"source_droplet_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"droplet_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDropletCopyCreate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	am := session.AppManager()
+
+	sourceApp := d.Get("source_app").(string)
+	destApp := d.Get("destination_app").(string)
+	timeout := time.Second * time.Duration(d.Get("timeout").(int))
+
+	sourceDroplet, err := am.GetCurrentDroplet(sourceApp)
+	if err != nil {
+		return err
+	}
+
+	droplet, err := am.CopyDroplet(sourceDroplet, destApp, timeout)
+	if err != nil {
+		return err
+	}
+	if err = am.SetCurrentDroplet(destApp, droplet); err != nil {
+		return err
+	}
+
+	if d.Get("restart").(bool) {
+		if err = am.StopApp(destApp, timeout); err != nil {
+			return err
+		}
+		if err = am.StartApp(destApp, timeout); err != nil {
+			return err
+		}
+	}
+
+	d.Set("source_droplet_id", sourceDroplet)
+	d.Set("droplet_id", droplet)
+	d.SetId(droplet)
+	return nil
+}
+
+func resourceDropletCopyRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	currentDroplet, err := session.AppManager().GetCurrentDroplet(d.Get("destination_app").(string))
+	if err != nil {
+		return err
+	}
+	if currentDroplet != d.Id() {
+		// another droplet was promoted since the copy; re-create to promote again
+		d.SetId("")
+	}
+	return nil
+}
+
+func resourceDropletCopyDelete(d *schema.ResourceData, meta interface{}) (err error) {
+
+	// the copied droplet remains assigned to the destination app; only the
+	// state entry is removed
+	return nil
+}
@@ -0,0 +1,71 @@
+package cloudfoundry
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestDropletCopy_fake(t *testing.T) {
+
+	f, session := newFakeSession(t)
+	defer f.Close()
+
+	f.Stub("GET", "/v3/apps/app-src/droplets/current", 200, map[string]interface{}{
+		"guid":  "droplet-src",
+		"state": "STAGED",
+	})
+	f.Stub("POST", "/v3/droplets", 201, map[string]interface{}{
+		"guid":  "droplet-new",
+		"state": "STAGED",
+	})
+	f.Stub("PATCH", "/v3/apps/app-dst/relationships/current_droplet", 200, map[string]interface{}{})
+
+	d := schema.TestResourceDataRaw(t, resourceDropletCopy().Schema, map[string]interface{}{
+		"source_app":      "app-src",
+		"destination_app": "app-dst",
+	})
+	if err := resourceDropletCopyCreate(d, session); err != nil {
+		t.Fatalf("create returned error: %s", err)
+	}
+	if d.Id() != "droplet-new" {
+		t.Errorf("expected the copied droplet GUID as ID, got '%s'", d.Id())
+	}
+	if v := d.Get("source_droplet_id").(string); v != "droplet-src" {
+		t.Errorf("expected the source droplet GUID to be recorded, got '%s'", v)
+	}
+
+	promoted := false
+	for _, r := range f.Requests() {
+		if r == "PATCH /v3/apps/app-dst/relationships/current_droplet" {
+			promoted = true
+		}
+	}
+	if !promoted {
+		t.Error("expected the copied droplet to be set as the destination app's current droplet")
+	}
+
+	// the copy stays current, so the state is kept
+	f.Stub("GET", "/v3/apps/app-dst/droplets/current", 200, map[string]interface{}{
+		"guid":  "droplet-new",
+		"state": "STAGED",
+	})
+	if err := resourceDropletCopyRead(d, session); err != nil {
+		t.Fatalf("read returned error: %s", err)
+	}
+	if len(d.Id()) == 0 {
+		t.Error("expected the ID to be kept while the copied droplet is current")
+	}
+
+	// another droplet promoted since the copy re-creates the resource
+	f.Stub("GET", "/v3/apps/app-dst/droplets/current", 200, map[string]interface{}{
+		"guid":  "droplet-other",
+		"state": "STAGED",
+	})
+	if err := resourceDropletCopyRead(d, session); err != nil {
+		t.Fatalf("read returned error: %s", err)
+	}
+	if len(d.Id()) != 0 {
+		t.Error("expected the ID to be cleared when another droplet was promoted")
+	}
+}
@@ -0,0 +1,121 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func resourceEvgEntry() *schema.Resource {
+
+	return &schema.Resource{
+
+		Create: resourceEvgEntryCreate,
+		Read:   resourceEvgEntryRead,
+		Update: resourceEvgEntryUpdate,
+		Delete: resourceEvgEntryDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				parts := strings.SplitN(d.Id(), "/", 2)
+				if len(parts) != 2 {
+					return nil, fmt.Errorf("import ID must be of the form '<group name>/<key>'")
+				}
+				d.Set("name", parts[0])
+				d.Set("key", parts[1])
+				return ImportStatePassthrough(d, meta)
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDefaultRunningStagingName,
+			},
+			"key": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"value": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceEvgEntryCreate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	if err = resourceEvgEntryUpdate(d, meta); err != nil {
+		return err
+	}
+	d.SetId(fmt.Sprintf("%s/%s", d.Get("name").(string), d.Get("key").(string)))
+	return nil
+}
+
+func resourceEvgEntryRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	var variables map[string]interface{}
+	if variables, err = session.EVGManager().GetEVG(d.Get("name").(string)); err != nil {
+		return err
+	}
+
+	v, ok := variables[d.Get("key").(string)]
+	if !ok {
+		d.SetId("")
+		return nil
+	}
+	d.Set("value", fmt.Sprintf("%v", v))
+	return nil
+}
+
+func resourceEvgEntryUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	evg := session.EVGManager()
+
+	name := d.Get("name").(string)
+
+	var variables map[string]interface{}
+	if variables, err = evg.GetEVG(name); err != nil {
+		return err
+	}
+	variables[d.Get("key").(string)] = d.Get("value").(string)
+
+	return evg.SetEVG(name, variables)
+}
+
+func resourceEvgEntryDelete(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	evg := session.EVGManager()
+
+	name := d.Get("name").(string)
+
+	var variables map[string]interface{}
+	if variables, err = evg.GetEVG(name); err != nil {
+		return err
+	}
+	delete(variables, d.Get("key").(string))
+
+	return evg.SetEVG(name, variables)
+}
@@ -0,0 +1,268 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+// internalRouteDefaultDomain - the internal domain set up by default for
+// container to container networking
+const internalRouteDefaultDomain = "apps.internal"
+
+func resourceInternalRoute() *schema.Resource {
+
+	return &schema.Resource{
+
+		Create: resourceInternalRouteCreate,
+		Read:   resourceInternalRouteRead,
+		Update: resourceInternalRouteUpdate,
+		Delete: resourceInternalRouteDelete,
+
+		Schema: map[string]*schema.Schema{
+
+			"hostname": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"domain": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  internalRouteDefaultDomain,
+				ForceNew: true,
+			},
+			"app": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"source_apps": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      resourceStringHash,
+			},
+			"protocol": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "tcp",
+				ForceNew: true,
+			},
+			"port": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  8080,
+				ForceNew: true,
+			},
+			"endpoint": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"mapping_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"route_created": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceInternalRouteCreate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	am := session.AppManager()
+	dm := session.DomainManager()
+	rm := session.RouteManager()
+
+	hostname := d.Get("hostname").(string)
+	domainName := d.Get("domain").(string)
+	appID := d.Get("app").(string)
+
+	domain, err := dm.FindSharedByName(domainName)
+	if err != nil {
+		return err
+	}
+
+	app, err := am.ReadApp(appID)
+	if err != nil {
+		return err
+	}
+
+	// remember whether the route was created here or adopted, so only a
+	// route this resource created is deleted again on destroy
+	routeCreated := false
+	route, err := rm.FindRoute(domainName, &hostname, nil, nil)
+	if err != nil {
+		if !strings.Contains(err.Error(), "not found") {
+			return err
+		}
+		if route, err = rm.CreateRoute(cfapi.CCRoute{
+			DomainGUID: domain.GUID,
+			SpaceGUID:  app.SpaceGUID,
+			Hostname:   &hostname,
+		}, false); err != nil {
+			return err
+		}
+		routeCreated = true
+	}
+
+	cleanup := newCleanupRegistry(session)
+	defer cleanup.runOnError(&err)
+	if routeCreated {
+		cleanup.register(fmt.Sprintf("internal route '%s'", route.ID), func() error {
+			return rm.DeleteRoute(route.ID)
+		})
+	}
+
+	mappingID, err := rm.CreateRouteMapping(route.ID, appID, nil)
+	if err != nil {
+		return err
+	}
+	cleanup.register(fmt.Sprintf("route mapping '%s'", mappingID), func() error {
+		return rm.DeleteRouteMapping(mappingID)
+	})
+
+	if err = internalRouteSetPolicies(session, d, internalRouteSourceApps(d), true); err != nil {
+		return err
+	}
+
+	d.SetId(route.ID)
+	d.Set("mapping_id", mappingID)
+	d.Set("route_created", routeCreated)
+	d.Set("endpoint", hostname+"."+domainName)
+	return nil
+}
+
+func resourceInternalRouteRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	rm := session.RouteManager()
+	npm := session.NetworkPolicyManager()
+
+	route, err := rm.ReadRoute(d.Id())
+	if err != nil {
+		if strings.Contains(err.Error(), "status code: 404") {
+			d.SetId("")
+			err = nil
+		}
+		return err
+	}
+	if route.Hostname != nil {
+		d.Set("hostname", *route.Hostname)
+		d.Set("endpoint", *route.Hostname+"."+d.Get("domain").(string))
+	}
+
+	appID := d.Get("app").(string)
+	protocol := d.Get("protocol").(string)
+	port := d.Get("port").(int)
+
+	policies, err := npm.ListPolicies(appID)
+	if err != nil {
+		return err
+	}
+	sourceApps := []interface{}{}
+	for _, p := range policies {
+		if p.Destination.ID == appID && p.Destination.Protocol == protocol &&
+			p.Destination.Ports.Start == port && p.Destination.Ports.End == port {
+			sourceApps = append(sourceApps, p.Source.ID)
+		}
+	}
+	d.Set("source_apps", schema.NewSet(resourceStringHash, sourceApps))
+
+	return nil
+}
+
+func resourceInternalRouteUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	if d.HasChange("source_apps") {
+		old, new := d.GetChange("source_apps")
+		remove, add := getListChanges(old, new)
+
+		if err = internalRouteSetPolicies(session, d, remove, false); err != nil {
+			return err
+		}
+		if err = internalRouteSetPolicies(session, d, add, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceInternalRouteDelete(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	rm := session.RouteManager()
+
+	if err = internalRouteSetPolicies(session, d, internalRouteSourceApps(d), false); err != nil {
+		return err
+	}
+
+	if mappingID := d.Get("mapping_id").(string); len(mappingID) > 0 {
+		if err = rm.DeleteRouteMapping(mappingID); err != nil && !strings.Contains(err.Error(), "status code: 404") {
+			return err
+		}
+	}
+	// an adopted route is left in place; only a route created by this
+	// resource is deleted with it
+	if d.Get("route_created").(bool) {
+		if err = rm.DeleteRoute(d.Id()); err != nil && !strings.Contains(err.Error(), "status code: 404") {
+			return err
+		}
+	}
+	return nil
+}
+
+// internalRouteSourceApps - the source app GUIDs declared on the resource
+func internalRouteSourceApps(d *schema.ResourceData) (sourceApps []string) {
+	for _, v := range d.Get("source_apps").(*schema.Set).List() {
+		sourceApps = append(sourceApps, v.(string))
+	}
+	return sourceApps
+}
+
+// internalRouteSetPolicies - adds or removes the network policies allowing
+// the given source apps to reach the destination app of the internal route
+func internalRouteSetPolicies(session *cfapi.Session, d *schema.ResourceData, sourceApps []string, add bool) (err error) {
+
+	if len(sourceApps) == 0 {
+		return nil
+	}
+
+	appID := d.Get("app").(string)
+	protocol := d.Get("protocol").(string)
+	port := d.Get("port").(int)
+
+	policies := []cfapi.CCNetworkPolicy{}
+	for _, sourceApp := range sourceApps {
+		policies = append(policies, cfapi.NewNetworkPolicy(sourceApp, appID, protocol, port, port))
+	}
+
+	npm := session.NetworkPolicyManager()
+	if add {
+		return npm.AddPolicies(policies)
+	}
+	return npm.RemovePolicies(policies)
+}
@@ -0,0 +1,118 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+const internalRouteResourceCommon = `
+
+data "cloudfoundry_org" "org" {
+    name = "pcfdev-org"
+}
+data "cloudfoundry_space" "space" {
+    name = "pcfdev-space"
+	org = "${data.cloudfoundry_org.org.id}"
+}
+
+resource "cloudfoundry_app" "backend" {
+	name = "test-internal-backend"
+	space = "${data.cloudfoundry_space.space.id}"
+	memory = "512"
+	disk_quota = "512"
+	timeout = 1800
+	url = "file://../tests/cf-acceptance-tests/assets/java-spring/java-spring.jar"
+}
+
+resource "cloudfoundry_app" "frontend" {
+	name = "test-internal-frontend"
+	space = "${data.cloudfoundry_space.space.id}"
+	memory = "512"
+	disk_quota = "512"
+	timeout = 1800
+	url = "file://../tests/cf-acceptance-tests/assets/java-spring/java-spring.jar"
+}
+`
+
+const internalRouteResource = internalRouteResourceCommon + `
+
+resource "cloudfoundry_internal_route" "backend" {
+	hostname = "test-internal-backend"
+	app = "${cloudfoundry_app.backend.id}"
+	source_apps = [ "${cloudfoundry_app.frontend.id}" ]
+}
+`
+
+func TestAccInternalRoute_normal(t *testing.T) {
+
+	ref := "cloudfoundry_internal_route.backend"
+
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+
+				resource.TestStep{
+					Config: internalRouteResource,
+					Check: resource.ComposeTestCheckFunc(
+						checkInternalRouteExists(ref),
+						resource.TestCheckResourceAttr(
+							ref, "hostname", "test-internal-backend"),
+						resource.TestCheckResourceAttr(
+							ref, "endpoint", "test-internal-backend."+internalRouteDefaultDomain),
+						resource.TestCheckResourceAttr(
+							ref, "source_apps.#", "1"),
+					),
+				},
+			},
+		})
+}
+
+func checkInternalRouteExists(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) (err error) {
+
+		session := testAccProvider.Meta().(*cfapi.Session)
+
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("internal route '%s' not found in terraform state", resource)
+		}
+
+		session.Log.DebugMessage(
+			"terraform state for resource '%s': %# v",
+			resource, rs)
+
+		id := rs.Primary.ID
+		attributes := rs.Primary.Attributes
+
+		route, err := session.RouteManager().ReadRoute(id)
+		if err != nil {
+			return err
+		}
+		if route.Hostname != nil {
+			if err = assertEquals(attributes, "hostname", *route.Hostname); err != nil {
+				return err
+			}
+		}
+
+		// the declared source apps must have a network policy to the
+		// destination app on the route's port
+		appID := attributes["app"]
+		policies, err := session.NetworkPolicyManager().ListPolicies(appID)
+		if err != nil {
+			return err
+		}
+		for _, policy := range policies {
+			if policy.Destination.ID == appID {
+				return nil
+			}
+		}
+		return fmt.Errorf("no network policy found for internal route app '%s'", appID)
+	}
+}
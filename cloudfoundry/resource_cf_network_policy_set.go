@@ -0,0 +1,219 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func resourceNetworkPolicySet() *schema.Resource {
+
+	return &schema.Resource{
+
+		Create: resourceNetworkPolicySetCreate,
+		Read:   resourceNetworkPolicySetRead,
+		Update: resourceNetworkPolicySetUpdate,
+		Delete: resourceNetworkPolicySetDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				d.Set("source_app", d.Id())
+				return ImportStatePassthrough(d, meta)
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			"source_app": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"policy": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"destination_app": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"protocol": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "tcp",
+						},
+						"port": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// parsePolicyPort - parses a port argument of the form "8080" or "8080-8090"
+func parsePolicyPort(port string) (start int, end int, err error) {
+
+	parts := strings.SplitN(port, "-", 2)
+	if start, err = strconv.Atoi(parts[0]); err != nil {
+		return 0, 0, fmt.Errorf("invalid port '%s'", port)
+	}
+	end = start
+	if len(parts) == 2 {
+		if end, err = strconv.Atoi(parts[1]); err != nil || end < start {
+			return 0, 0, fmt.Errorf("invalid port range '%s'", port)
+		}
+	}
+	return start, end, nil
+}
+
+// formatPolicyPort -
+func formatPolicyPort(start int, end int) string {
+	if start == end {
+		return strconv.Itoa(start)
+	}
+	return fmt.Sprintf("%d-%d", start, end)
+}
+
+// networkPolicySetDesired - builds the declared policies for the source app
+func networkPolicySetDesired(d *schema.ResourceData) (policies []cfapi.CCNetworkPolicy, err error) {
+
+	sourceApp := d.Get("source_app").(string)
+
+	for _, p := range d.Get("policy").(*schema.Set).List() {
+		policy := p.(map[string]interface{})
+		start, end, err := parsePolicyPort(policy["port"].(string))
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, cfapi.NewNetworkPolicy(
+			sourceApp, policy["destination_app"].(string), policy["protocol"].(string), start, end))
+	}
+	return policies, nil
+}
+
+// networkPolicySetExisting - lists the policies currently attached to the source app
+func networkPolicySetExisting(sourceApp string, nm *cfapi.NetworkPolicyManager) (policies []cfapi.CCNetworkPolicy, err error) {
+
+	all, err := nm.ListPolicies(sourceApp)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range all {
+		if p.Source.ID == sourceApp {
+			policies = append(policies, p)
+		}
+	}
+	return policies, nil
+}
+
+// networkPolicySetSync - makes the foundation match the declared policies,
+// removing any policy of the source app that is not declared
+func networkPolicySetSync(d *schema.ResourceData, nm *cfapi.NetworkPolicyManager) (err error) {
+
+	desired, err := networkPolicySetDesired(d)
+	if err != nil {
+		return err
+	}
+	existing, err := networkPolicySetExisting(d.Get("source_app").(string), nm)
+	if err != nil {
+		return err
+	}
+
+	var toAdd, toRemove []cfapi.CCNetworkPolicy
+	for _, p := range desired {
+		if !containsNetworkPolicy(existing, p) {
+			toAdd = append(toAdd, p)
+		}
+	}
+	for _, p := range existing {
+		if !containsNetworkPolicy(desired, p) {
+			toRemove = append(toRemove, p)
+		}
+	}
+
+	if err = nm.AddPolicies(toAdd); err != nil {
+		return err
+	}
+	return nm.RemovePolicies(toRemove)
+}
+
+// containsNetworkPolicy -
+func containsNetworkPolicy(policies []cfapi.CCNetworkPolicy, policy cfapi.CCNetworkPolicy) bool {
+	for _, p := range policies {
+		if p == policy {
+			return true
+		}
+	}
+	return false
+}
+
+func resourceNetworkPolicySetCreate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	if err = networkPolicySetSync(d, session.NetworkPolicyManager()); err != nil {
+		return err
+	}
+	d.SetId(d.Get("source_app").(string))
+	return nil
+}
+
+func resourceNetworkPolicySetRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	existing, err := networkPolicySetExisting(d.Get("source_app").(string), session.NetworkPolicyManager())
+	if err != nil {
+		return err
+	}
+
+	policies := []interface{}{}
+	for _, p := range existing {
+		policies = append(policies, map[string]interface{}{
+			"destination_app": p.Destination.ID,
+			"protocol":        p.Destination.Protocol,
+			"port":            formatPolicyPort(p.Destination.Ports.Start, p.Destination.Ports.End),
+		})
+	}
+	d.Set("policy", policies)
+	return nil
+}
+
+func resourceNetworkPolicySetUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	return networkPolicySetSync(d, session.NetworkPolicyManager())
+}
+
+func resourceNetworkPolicySetDelete(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	nm := session.NetworkPolicyManager()
+
+	existing, err := networkPolicySetExisting(d.Get("source_app").(string), nm)
+	if err != nil {
+		return err
+	}
+	return nm.RemovePolicies(existing)
+}
@@ -0,0 +1,143 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+const networkPolicySetResourceCommon = `
+
+data "cloudfoundry_org" "org" {
+    name = "pcfdev-org"
+}
+data "cloudfoundry_space" "space" {
+    name = "pcfdev-space"
+	org = "${data.cloudfoundry_org.org.id}"
+}
+
+resource "cloudfoundry_app" "source" {
+	name = "test-policy-source"
+	space = "${data.cloudfoundry_space.space.id}"
+	memory = "512"
+	disk_quota = "512"
+	timeout = 1800
+	url = "file://../tests/cf-acceptance-tests/assets/java-spring/java-spring.jar"
+}
+
+resource "cloudfoundry_app" "destination" {
+	name = "test-policy-destination"
+	space = "${data.cloudfoundry_space.space.id}"
+	memory = "512"
+	disk_quota = "512"
+	timeout = 1800
+	url = "file://../tests/cf-acceptance-tests/assets/java-spring/java-spring.jar"
+}
+`
+
+const networkPolicySetResource = networkPolicySetResourceCommon + `
+
+resource "cloudfoundry_network_policy_set" "source" {
+	source_app = "${cloudfoundry_app.source.id}"
+
+	policy {
+		destination_app = "${cloudfoundry_app.destination.id}"
+		port = "8080"
+	}
+}
+`
+
+const networkPolicySetResourceUpdate = networkPolicySetResourceCommon + `
+
+resource "cloudfoundry_network_policy_set" "source" {
+	source_app = "${cloudfoundry_app.source.id}"
+
+	policy {
+		destination_app = "${cloudfoundry_app.destination.id}"
+		port = "9090-9091"
+		protocol = "udp"
+	}
+}
+`
+
+func TestAccNetworkPolicySet_normal(t *testing.T) {
+
+	ref := "cloudfoundry_network_policy_set.source"
+
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckNetworkPolicySetDestroyed(ref),
+			Steps: []resource.TestStep{
+
+				resource.TestStep{
+					Config: networkPolicySetResource,
+					Check: resource.ComposeTestCheckFunc(
+						checkNetworkPolicySetExists(ref, 1),
+						resource.TestCheckResourceAttr(
+							ref, "policy.#", "1"),
+					),
+				},
+				resource.TestStep{
+					Config: networkPolicySetResourceUpdate,
+					Check: resource.ComposeTestCheckFunc(
+						checkNetworkPolicySetExists(ref, 1),
+						resource.TestCheckResourceAttr(
+							ref, "policy.#", "1"),
+					),
+				},
+			},
+		})
+}
+
+func checkNetworkPolicySetExists(resource string, expected int) resource.TestCheckFunc {
+
+	return func(s *terraform.State) (err error) {
+
+		session := testAccProvider.Meta().(*cfapi.Session)
+
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("network policy set '%s' not found in terraform state", resource)
+		}
+
+		session.Log.DebugMessage(
+			"terraform state for resource '%s': %# v",
+			resource, rs)
+
+		policies, err := networkPolicySetExisting(rs.Primary.ID, session.NetworkPolicyManager())
+		if err != nil {
+			return err
+		}
+		if len(policies) != expected {
+			return fmt.Errorf(
+				"expected %d network policies for source app '%s', found %d", expected, rs.Primary.ID, len(policies))
+		}
+		return nil
+	}
+}
+
+func testAccCheckNetworkPolicySetDestroyed(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+
+		session := testAccProvider.Meta().(*cfapi.Session)
+
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return nil
+		}
+		policies, err := networkPolicySetExisting(rs.Primary.ID, session.NetworkPolicyManager())
+		if err != nil {
+			return err
+		}
+		if len(policies) > 0 {
+			return fmt.Errorf("source app '%s' still has network policies in cloud foundry", rs.Primary.ID)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,114 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+// orgStatusSuspended - org status value while a tenant is frozen
+const orgStatusSuspended = "suspended"
+
+// orgStatusActive - org status value for normal operation
+const orgStatusActive = "active"
+
+func resourceOrgSuspension() *schema.Resource {
+
+	return &schema.Resource{
+
+		Create: resourceOrgSuspensionCreate,
+		Read:   resourceOrgSuspensionRead,
+		Update: resourceOrgSuspensionUpdate,
+		Delete: resourceOrgSuspensionDelete,
+
+		Schema: map[string]*schema.Schema{
+
+			"org": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"suspended": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func resourceOrgSuspensionCreate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	orgID := d.Get("org").(string)
+	if err = setOrgSuspension(session, orgID, d.Get("suspended").(bool)); err != nil {
+		return err
+	}
+	d.SetId(orgID)
+	return nil
+}
+
+func resourceOrgSuspensionRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	org, err := session.OrgManager().ReadOrg(d.Id())
+	if err != nil {
+		if strings.Contains(err.Error(), "status code: 404") {
+			d.SetId("")
+			err = nil
+		}
+		return err
+	}
+
+	d.Set("org", org.ID)
+	d.Set("suspended", org.Status == orgStatusSuspended)
+	return nil
+}
+
+func resourceOrgSuspensionUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+	return setOrgSuspension(session, d.Id(), d.Get("suspended").(bool))
+}
+
+func resourceOrgSuspensionDelete(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+	// removing the resource reactivates the org
+	return setOrgSuspension(session, d.Id(), false)
+}
+
+// setOrgSuspension - flips the status of the given org
+func setOrgSuspension(session *cfapi.Session, orgID string, suspended bool) (err error) {
+
+	om := session.OrgManager()
+
+	org, err := om.ReadOrg(orgID)
+	if err != nil {
+		return err
+	}
+	if suspended {
+		org.Status = orgStatusSuspended
+	} else {
+		org.Status = orgStatusActive
+	}
+
+	session.Log.DebugMessage("Setting status of org %s to '%s'", orgID, org.Status)
+	return om.UpdateOrg(org)
+}
@@ -0,0 +1,70 @@
+package cloudfoundry
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestOrgSuspension_fake(t *testing.T) {
+
+	f, session := newFakeSession(t)
+	defer f.Close()
+
+	orgResource := func(status string) map[string]interface{} {
+		return map[string]interface{}{
+			"metadata": map[string]interface{}{"guid": "org-one"},
+			"entity":   map[string]interface{}{"name": "tenant", "status": status},
+		}
+	}
+	f.Stub("GET", "/v2/organizations/org-one", 200, orgResource("active"))
+	f.Stub("PUT", "/v2/organizations/org-one", 201, orgResource("suspended"))
+
+	d := schema.TestResourceDataRaw(t, resourceOrgSuspension().Schema, map[string]interface{}{
+		"org":       "org-one",
+		"suspended": true,
+	})
+	if err := resourceOrgSuspensionCreate(d, session); err != nil {
+		t.Fatalf("create returned error: %s", err)
+	}
+	if d.Id() != "org-one" {
+		t.Errorf("expected the org GUID as ID, got '%s'", d.Id())
+	}
+
+	suspended := false
+	for _, r := range f.Requests() {
+		if r == "PUT /v2/organizations/org-one" {
+			suspended = true
+		}
+	}
+	if !suspended {
+		t.Error("expected the org to be updated with the suspended status")
+	}
+
+	// the read reflects the status of the org
+	f.Stub("GET", "/v2/organizations/org-one", 200, orgResource("suspended"))
+	if err := resourceOrgSuspensionRead(d, session); err != nil {
+		t.Fatalf("read returned error: %s", err)
+	}
+	if !d.Get("suspended").(bool) {
+		t.Error("expected 'suspended' to be true for a suspended org")
+	}
+
+	// removing the resource reactivates the org
+	if err := resourceOrgSuspensionDelete(d, session); err != nil {
+		t.Errorf("delete returned error: %s", err)
+	}
+
+	// an org deleted out of band clears the state
+	f.Stub("GET", "/v2/organizations/org-one", 404, map[string]interface{}{
+		"code":        30003,
+		"error_code":  "CF-OrganizationNotFound",
+		"description": "The organization could not be found",
+	})
+	if err := resourceOrgSuspensionRead(d, session); err != nil {
+		t.Fatalf("read returned error: %s", err)
+	}
+	if len(d.Id()) != 0 {
+		t.Error("expected the ID to be cleared for a removed org")
+	}
+}
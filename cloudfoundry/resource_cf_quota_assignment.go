@@ -0,0 +1,128 @@
+package cloudfoundry
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func resourceQuotaAssignment() *schema.Resource {
+
+	return &schema.Resource{
+
+		Create: resourceQuotaAssignmentCreate,
+		Read:   resourceQuotaAssignmentRead,
+		Delete: resourceQuotaAssignmentDelete,
+
+		Schema: map[string]*schema.Schema{
+
+			"quota": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"org": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"space"},
+			},
+			"space": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"org"},
+			},
+			"fallback_quota": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Name of the org quota assigned in place of the removed one on destroy.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "default",
+			},
+		},
+	}
+}
+
+// quotaAssignmentTarget - resolves the quota type and the org or space the
+// assignment applies to
+func quotaAssignmentTarget(d *schema.ResourceData) (t cfapi.QuotaType, targetID string, err error) {
+
+	if v, ok := d.GetOk("org"); ok {
+		return cfapi.OrgQuota, v.(string), nil
+	}
+	if v, ok := d.GetOk("space"); ok {
+		return cfapi.SpaceQuota, v.(string), nil
+	}
+	return t, "", fmt.Errorf("one of 'org' or 'space' needs to be provided")
+}
+
+func resourceQuotaAssignmentCreate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	t, targetID, err := quotaAssignmentTarget(d)
+	if err != nil {
+		return err
+	}
+	quota := d.Get("quota").(string)
+
+	if err = session.QuotaManager().AssignQuota(t, quota, targetID); err != nil {
+		return err
+	}
+	d.SetId(fmt.Sprintf("%s/%s", quota, targetID))
+	return nil
+}
+
+func resourceQuotaAssignmentRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	t, targetID, err := quotaAssignmentTarget(d)
+	if err != nil {
+		return err
+	}
+
+	var assignedQuota string
+	if t == cfapi.OrgQuota {
+		org, err := session.OrgManager().ReadOrg(targetID)
+		if err != nil {
+			return err
+		}
+		assignedQuota = org.QuotaGUID
+	} else {
+		space, err := session.SpaceManager().ReadSpace(targetID)
+		if err != nil {
+			return err
+		}
+		assignedQuota = space.QuotaGUID
+	}
+
+	if assignedQuota != d.Get("quota").(string) {
+		// the assignment was changed outside of terraform
+		d.SetId("")
+	}
+	return nil
+}
+
+func resourceQuotaAssignmentDelete(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	t, targetID, err := quotaAssignmentTarget(d)
+	if err != nil {
+		return err
+	}
+
+	return session.QuotaManager().UnassignQuota(t, d.Get("quota").(string), targetID, d.Get("fallback_quota").(string))
+}
@@ -0,0 +1,73 @@
+package cloudfoundry
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestQuotaAssignment_fake(t *testing.T) {
+
+	f, session := newFakeSession(t)
+	defer f.Close()
+
+	orgResource := func(quotaGUID string) map[string]interface{} {
+		return map[string]interface{}{
+			"metadata": map[string]interface{}{"guid": "org-one"},
+			"entity":   map[string]interface{}{"name": "tenant", "quota_definition_guid": quotaGUID},
+		}
+	}
+	f.Stub("PUT", "/v2/organizations/org-one", 201, orgResource("quota-one"))
+	f.Stub("GET", "/v2/organizations/org-one", 200, orgResource("quota-one"))
+
+	d := schema.TestResourceDataRaw(t, resourceQuotaAssignment().Schema, map[string]interface{}{
+		"quota": "quota-one",
+		"org":   "org-one",
+	})
+	if err := resourceQuotaAssignmentCreate(d, session); err != nil {
+		t.Fatalf("create returned error: %s", err)
+	}
+	if d.Id() != "quota-one/org-one" {
+		t.Errorf("unexpected ID '%s'", d.Id())
+	}
+
+	if err := resourceQuotaAssignmentRead(d, session); err != nil {
+		t.Fatalf("read returned error: %s", err)
+	}
+	if len(d.Id()) == 0 {
+		t.Error("expected the ID to be kept while the quota is still assigned")
+	}
+
+	// an assignment changed outside of terraform clears the state
+	f.Stub("GET", "/v2/organizations/org-one", 200, orgResource("quota-other"))
+	if err := resourceQuotaAssignmentRead(d, session); err != nil {
+		t.Fatalf("read returned error: %s", err)
+	}
+	if len(d.Id()) != 0 {
+		t.Error("expected the ID to be cleared when the quota was re-assigned out of band")
+	}
+
+	// the destroy assigns the fallback quota in place of the removed one
+	d.SetId("quota-one/org-one")
+	f.Stub("GET", "/v2/quota_definitions", 200, map[string]interface{}{
+		"next_url": nil,
+		"resources": []interface{}{
+			map[string]interface{}{
+				"metadata": map[string]interface{}{"guid": "quota-default"},
+				"entity":   map[string]interface{}{"name": "default"},
+			},
+		},
+	})
+	if err := resourceQuotaAssignmentDelete(d, session); err != nil {
+		t.Fatalf("delete returned error: %s", err)
+	}
+	resolvedFallback := false
+	for _, r := range f.Requests() {
+		if r == "GET /v2/quota_definitions" {
+			resolvedFallback = true
+		}
+	}
+	if !resolvedFallback {
+		t.Error("expected the fallback quota to be resolved by name on destroy")
+	}
+}
@@ -1,9 +1,12 @@
 package cloudfoundry
 
 import (
+	"crypto/sha256"
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -23,6 +26,8 @@ func resourceRoute() *schema.Resource {
 			State: ImportStatePassthrough,
 		},
 
+		CustomizeDiff: resourceRouteCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 
 			"domain": &schema.Schema{
@@ -33,9 +38,37 @@ func resourceRoute() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"allow_space_move": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"hostname": &schema.Schema{
-				Type:     schema.TypeString,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"hostname_prefix"},
+			},
+			"hostname_prefix": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"hostname"},
+			},
+			"auto_suffix_on_conflict": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"adopt_existing": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"apply_name_affixes": &schema.Schema{
+				Type:     schema.TypeBool,
 				Optional: true,
+				Default:  false,
 			},
 			"port": &schema.Schema{
 				Type:          schema.TypeInt,
@@ -59,6 +92,22 @@ func resourceRoute() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"exclusive": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"verify_listening": &schema.Schema{
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"path"},
+			},
+			"verify_timeout": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  60,
+			},
 			"target": &schema.Schema{
 				Type:     schema.TypeSet,
 				Set:      routeTargetHash,
@@ -109,8 +158,14 @@ func resourceRouteCreate(d *schema.ResourceData, meta interface{}) (err error) {
 		SpaceGUID:  d.Get("space").(string),
 	}
 
+	hostnameBase := ""
 	if v, ok := d.GetOk("hostname"); ok {
-		vv := v.(string)
+		hostnameBase = v.(string)
+	} else if v, ok := d.GetOk("hostname_prefix"); ok {
+		hostnameBase = v.(string)
+	}
+	if len(hostnameBase) > 0 {
+		vv := appliedName(d, session, hostnameBase)
 		route.Hostname = &vv
 	}
 	if v, ok := d.GetOk("port"); ok {
@@ -130,20 +185,33 @@ func resourceRouteCreate(d *schema.ResourceData, meta interface{}) (err error) {
 	rm := session.RouteManager()
 
 	// Create route
-	if route, err = rm.CreateRoute(route, randomPort); err != nil {
+	created, err := rm.CreateRoute(route, randomPort)
+	if err != nil && d.Get("adopt_existing").(bool) && isRouteConflictError(err) {
+		// interrupted applies can leave a reserved but unmapped route behind;
+		// adopt it into state instead of failing on the conflict
+		if adopted, ok := adoptExistingRoute(rm, session, route); ok {
+			created, err = adopted, nil
+		}
+	}
+	if err != nil && route.Hostname != nil &&
+		d.Get("auto_suffix_on_conflict").(bool) && isRouteConflictError(err) {
+		// the suffix is derived from the target space and domain so re-runs
+		// of the same configuration resolve to the same hostname
+		suffixed := *route.Hostname + "-" + routeConflictSuffix(d)
+		session.Log.DebugMessage("Hostname '%s' is taken, retrying with '%s'", *route.Hostname, suffixed)
+		route.Hostname = &suffixed
+		created, err = rm.CreateRoute(route, randomPort)
+	}
+	if err != nil {
 		return err
 	}
+	route = created
 	// Delete route if an error occurs
-	defer func() {
-		e := &err
-		if *e == nil {
-			return
-		}
-		err = rm.DeleteRoute(route.ID)
-		if err != nil {
-			panic(err)
-		}
-	}()
+	cleanup := newCleanupRegistry(session)
+	defer cleanup.runOnError(&err)
+	cleanup.register(fmt.Sprintf("route '%s'", route.ID), func() error {
+		return rm.DeleteRoute(route.ID)
+	})
 
 	if err = setRouteArguments(session, route, d); err != nil {
 		return err
@@ -158,7 +226,23 @@ func resourceRouteCreate(d *schema.ResourceData, meta interface{}) (err error) {
 		session.Log.DebugMessage("Mapped route targets: %# v", d.Get("target"))
 	}
 
+	if d.Get("exclusive").(bool) {
+		if err = removeForeignRouteMappings(route.ID, d.Get("target").(*schema.Set), rm, session.Log); err != nil {
+			return err
+		}
+	}
+
+	if d.Get("verify_listening").(bool) {
+		if route.Port == nil || *route.Port <= 0 {
+			return fmt.Errorf("the 'verify_listening' attribute is only supported for TCP routes with a port")
+		}
+		if err = verifyRouteListening(d.Get("endpoint").(string), d.Get("verify_timeout").(int), session.Log); err != nil {
+			return err
+		}
+	}
+
 	d.SetId(route.ID)
+	annotateManagedResource(session, "routes", route.ID)
 	return err
 }
 
@@ -184,11 +268,16 @@ func resourceRouteRead(d *schema.ResourceData, meta interface{}) (err error) {
 		return err
 	}
 
-	if _, ok := d.GetOk("target"); ok || IsImportState(d) {
+	if _, ok := d.GetOk("target"); ok || d.Get("exclusive").(bool) || IsImportState(d) {
 		var mappings []map[string]interface{}
 		if mappings, err = rm.ReadRouteMappingsByRoute(id); err != nil {
 			return err
 		}
+		if !d.Get("exclusive").(bool) && !IsImportState(d) {
+			// without exclusive ownership only reflect mappings created
+			// through this resource, leaving mappings made by other tools alone
+			mappings = filterRouteMappings(mappings, d.Get("target").(*schema.Set))
+		}
 		if len(mappings) > 0 {
 			d.Set("target", mappings)
 		}
@@ -202,8 +291,24 @@ func resourceRouteUpdate(d *schema.ResourceData, meta interface{}) (err error) {
 	if session == nil {
 		return fmt.Errorf("client is nil")
 	}
+	if err = validateManagedResource(session, "routes", d.Id()); err != nil {
+		return err
+	}
 	rm := session.RouteManager()
 
+	if d.HasChange("space") {
+		// the Cloud Controller refuses to change the space of an existing
+		// route; with 'allow_space_move' set the transfer is performed here
+		// as a delete and recreate preserving host, port, path and mappings
+		if err = resourceRouteMoveSpace(d, session); err != nil {
+			return err
+		}
+		if d.Get("exclusive").(bool) {
+			return removeForeignRouteMappings(d.Id(), d.Get("target").(*schema.Set), rm, session.Log)
+		}
+		return nil
+	}
+
 	route := cfapi.CCRoute{
 		ID: d.Id(),
 	}
@@ -212,6 +317,10 @@ func resourceRouteUpdate(d *schema.ResourceData, meta interface{}) (err error) {
 	route.DomainGUID = *getChangedValueString("domain", &update, d)
 	route.SpaceGUID = *getChangedValueString("space", &update, d)
 	route.Hostname = getChangedValueString("hostname", &update, d)
+	if route.Hostname != nil {
+		vv := appliedName(d, session, *route.Hostname)
+		route.Hostname = &vv
+	}
 
 	if update {
 		if route, err = rm.UpdateRoute(route); err != nil {
@@ -238,6 +347,12 @@ func resourceRouteUpdate(d *schema.ResourceData, meta interface{}) (err error) {
 		d.Set("target", t)
 		session.Log.DebugMessage("Updated route target mappings: %# v", d.Get("target"))
 	}
+
+	if d.Get("exclusive").(bool) {
+		if err = removeForeignRouteMappings(d.Id(), d.Get("target").(*schema.Set), rm, session.Log); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -247,6 +362,9 @@ func resourceRouteDelete(d *schema.ResourceData, meta interface{}) (err error) {
 	if session == nil {
 		return fmt.Errorf("client is nil")
 	}
+	if err = validateManagedResource(session, "routes", d.Id()); err != nil {
+		return err
+	}
 	rm := session.RouteManager()
 
 	if targets, ok := d.GetOk("target"); ok {
@@ -266,7 +384,7 @@ func setRouteArguments(session *cfapi.Session, route cfapi.CCRoute, d *schema.Re
 	d.Set("domain", route.DomainGUID)
 	d.Set("space", route.SpaceGUID)
 	if route.Hostname != nil {
-		d.Set("hostname", route.Hostname)
+		d.Set("hostname", strippedName(d, session, *route.Hostname))
 	}
 	if route.Port != nil {
 		d.Set("port", route.Port)
@@ -342,3 +460,248 @@ func removeTargets(
 	}
 	return nil
 }
+
+// filterRouteMappings - keeps only the mappings that are already tracked in
+// the given state set, identified by their mapping id
+func filterRouteMappings(mappings []map[string]interface{}, state *schema.Set) []map[string]interface{} {
+
+	known := make(map[string]bool)
+	for _, v := range state.List() {
+		known[v.(map[string]interface{})["mapping_id"].(string)] = true
+	}
+
+	filtered := []map[string]interface{}{}
+	for _, m := range mappings {
+		if known[m["mapping_id"].(string)] {
+			filtered = append(filtered, m)
+		}
+	}
+	return filtered
+}
+
+// removeForeignRouteMappings - deletes all mappings of the route that are not
+// tracked by this resource, enforcing exclusive ownership of the route
+func removeForeignRouteMappings(
+	id string,
+	targets *schema.Set,
+	rm *cfapi.RouteManager,
+	log *cfapi.Logger) (err error) {
+
+	owned := make(map[string]bool)
+	for _, v := range targets.List() {
+		owned[v.(map[string]interface{})["mapping_id"].(string)] = true
+	}
+
+	mappings, err := rm.ReadRouteMappingsByRoute(id)
+	if err != nil {
+		return err
+	}
+	for _, m := range mappings {
+		mappingID := m["mapping_id"].(string)
+		if !owned[mappingID] {
+			log.DebugMessage("Deleting foreign route mapping with id '%s' to app instance '%s'.", mappingID, m["app"])
+			if err = rm.DeleteRouteMapping(mappingID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resourceRouteMoveSpace - transfers the route to another space by deleting
+// and recreating it with the same host, port and path and re-creating its
+// mappings, keeping the window without the route as short as possible
+func resourceRouteMoveSpace(d *schema.ResourceData, session *cfapi.Session) (err error) {
+
+	rm := session.RouteManager()
+
+	targets := getListOfStructs(d.Get("target").(*schema.Set).List())
+	if err = removeTargets(targets, rm, session.Log); err != nil {
+		return err
+	}
+	if err = rm.DeleteRoute(d.Id()); err != nil {
+		return err
+	}
+
+	route := cfapi.CCRoute{
+		DomainGUID: d.Get("domain").(string),
+		SpaceGUID:  d.Get("space").(string),
+	}
+	if v, ok := d.GetOk("hostname"); ok {
+		vv := v.(string)
+		route.Hostname = &vv
+	}
+	if v, ok := d.GetOk("port"); ok {
+		vv := v.(int)
+		route.Port = &vv
+	}
+	if v, ok := d.GetOk("path"); ok {
+		vv := v.(string)
+		route.Path = &vv
+	}
+
+	if route, err = rm.CreateRoute(route, false); err != nil {
+		return err
+	}
+	d.SetId(route.ID)
+	annotateManagedResource(session, "routes", route.ID)
+
+	if len(targets) > 0 {
+		var t interface{}
+		if t, err = addTargets(route.ID, targets, rm, session.Log); err != nil {
+			return err
+		}
+		d.Set("target", t)
+	}
+	return setRouteArguments(session, route, d)
+}
+
+// verifyRouteListening - attempts to open a TCP connection to the route
+// endpoint until it succeeds or the timeout elapses, catching router-group
+// misconfigurations right after route creation
+func verifyRouteListening(endpoint string, timeout int, log *cfapi.Logger) (err error) {
+
+	deadline := time.Now().Add(time.Second * time.Duration(timeout))
+	for {
+		var conn net.Conn
+		if conn, err = net.DialTimeout("tcp", endpoint, time.Second*5); err == nil {
+			conn.Close()
+			log.DebugMessage("TCP route endpoint '%s' verified to be listening.", endpoint)
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf(
+				"TCP route endpoint '%s' was not reachable after %d seconds: %s",
+				endpoint, timeout, err.Error())
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// resourceRouteCustomizeDiff - validates path and port attributes against the
+// type of the referenced domain at plan time, as the Cloud Controller only
+// rejects the mismatch during the apply with an opaque CF-RouteInvalid error
+func resourceRouteCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) (err error) {
+
+	session, ok := meta.(*cfapi.Session)
+	if !ok || session == nil {
+		return nil
+	}
+
+	// without explicit permission to move the route between spaces, a space
+	// change keeps its replace semantics
+	if len(diff.Id()) > 0 && diff.HasChange("space") && !diff.Get("allow_space_move").(bool) {
+		if err = diff.ForceNew("space"); err != nil {
+			return err
+		}
+	}
+
+	domainID := diff.Get("domain").(string)
+	if len(domainID) == 0 {
+		// the domain is created in the same plan so its type cannot be
+		// inspected yet
+		return nil
+	}
+	domain, err := session.DomainManager().FindDomain(domainID)
+	if err != nil {
+		session.Log.DebugMessage("Skipping route validation, unable to read domain %s: %s", domainID, err.Error())
+		return nil
+	}
+
+	if len(session.ApprovedRouteDomains) > 0 {
+		approved := false
+		for _, name := range session.ApprovedRouteDomains {
+			if name == domain.Name {
+				approved = true
+				break
+			}
+		}
+		if !approved {
+			return fmt.Errorf(
+				"domain '%s' is not listed in the provider's 'approved_route_domains'; routes may only be created on approved domains",
+				domain.Name)
+		}
+	}
+
+	if domain.RouterType == "tcp" {
+		if v, ok := diff.GetOk("path"); ok && len(v.(string)) > 0 {
+			return fmt.Errorf(
+				"'path' cannot be used with domain '%s' as it is a TCP domain; paths are only supported on HTTP domains",
+				domain.Name)
+		}
+		return nil
+	}
+
+	if _, ok := diff.GetOk("port"); ok {
+		return fmt.Errorf(
+			"'port' cannot be used with domain '%s' as it is an HTTP domain; ports are only supported on TCP domains",
+			domain.Name)
+	}
+	if diff.Get("random_port").(bool) {
+		return fmt.Errorf(
+			"'random_port' cannot be used with domain '%s' as it is an HTTP domain; ports are only supported on TCP domains",
+			domain.Name)
+	}
+	return nil
+}
+
+// isRouteConflictError - true when the Cloud Controller rejected the route
+// because its host, port or path is already taken
+func isRouteConflictError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "cf-routehosttaken") ||
+		strings.Contains(msg, "cf-routeporttaken") ||
+		strings.Contains(msg, "cf-routepathtaken") ||
+		(strings.Contains(msg, "route") && strings.Contains(msg, "taken"))
+}
+
+// adoptExistingRoute - looks for an existing route matching the desired one
+// and takes it over when no application is mapped to it, moving it to the
+// configured space if necessary; owned routes are never adopted
+func adoptExistingRoute(rm *cfapi.RouteManager, session *cfapi.Session, route cfapi.CCRoute) (cfapi.CCRoute, bool) {
+
+	hostname := ""
+	if route.Hostname != nil {
+		hostname = *route.Hostname
+	}
+	existing, err := rm.FindRouteInDomain(route.DomainGUID, hostname)
+	if err != nil {
+		return cfapi.CCRoute{}, false
+	}
+	if !routeAttributeEqual(route.Path, existing.Path) {
+		return cfapi.CCRoute{}, false
+	}
+	if (route.Port == nil) != (existing.Port == nil) ||
+		(route.Port != nil && *route.Port != *existing.Port) {
+		return cfapi.CCRoute{}, false
+	}
+	mappings, err := rm.ReadRouteMappingsByRoute(existing.ID)
+	if err != nil || len(mappings) > 0 {
+		return cfapi.CCRoute{}, false
+	}
+	if existing.SpaceGUID != route.SpaceGUID {
+		existing.SpaceGUID = route.SpaceGUID
+		if existing, err = rm.UpdateRoute(existing); err != nil {
+			return cfapi.CCRoute{}, false
+		}
+	}
+	session.Log.DebugMessage("Adopted existing unmapped route '%s'.", existing.ID)
+	return existing, true
+}
+
+// routeAttributeEqual - nil-safe comparison of optional route attributes
+func routeAttributeEqual(a, b *string) bool {
+	if (a == nil) != (b == nil) {
+		return false
+	}
+	return a == nil || *a == *b
+}
+
+// routeConflictSuffix - a deterministic suffix for resolving hostname
+// collisions, derived from the route's space, domain and path so the same
+// configuration always resolves to the same hostname
+func routeConflictSuffix(d *schema.ResourceData) string {
+	key := fmt.Sprintf("%s|%s|%s",
+		d.Get("space").(string), d.Get("domain").(string), d.Get("path").(string))
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(key)))[:6]
+}
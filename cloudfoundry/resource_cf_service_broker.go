@@ -43,6 +43,11 @@ func resourceServiceBroker() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"catalog_hash": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
 			"service_plans": &schema.Schema{
 				Type:     schema.TypeMap,
 				Computed: true,
@@ -113,6 +118,12 @@ func resourceServiceBrokerUpdate(d *schema.ResourceData, meta interface{}) (err
 
 	id, name, url, username, password, space := getSchemaAttributes(d)
 
+	if d.HasChange("catalog_hash") {
+		// the broker update below makes the Cloud Controller re-fetch the
+		// catalog, picking up any new or changed plans
+		session.Log.DebugMessage("Refreshing catalog of service broker %s as 'catalog_hash' changed", name)
+	}
+
 	sm := session.ServiceManager()
 	if _, err = sm.UpdateServiceBroker(id, name, url, username, password, space); err != nil {
 		d.SetId("")
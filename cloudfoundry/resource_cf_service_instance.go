@@ -12,6 +12,10 @@ import (
 	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
 )
 
+// defaultServiceInstanceTimeout - default wait for asynchronous service
+// instance operations, unless overridden per resource or provider wide
+const defaultServiceInstanceTimeout = 15 * time.Minute
+
 func resourceServiceInstance() *schema.Resource {
 
 	return &schema.Resource{
@@ -25,10 +29,12 @@ func resourceServiceInstance() *schema.Resource {
 			State: resourceServiceInstanceImport,
 		},
 
+		CustomizeDiff: resourceServiceInstanceCustomizeDiff,
+
 		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(15 * time.Minute),
-			Update: schema.DefaultTimeout(15 * time.Minute),
-			Delete: schema.DefaultTimeout(15 * time.Minute),
+			Create: schema.DefaultTimeout(defaultServiceInstanceTimeout),
+			Update: schema.DefaultTimeout(defaultServiceInstanceTimeout),
+			Delete: schema.DefaultTimeout(defaultServiceInstanceTimeout),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -37,9 +43,26 @@ func resourceServiceInstance() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"apply_name_affixes": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"service_plan": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"service", "plan"},
+			},
+			"service": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"service_plan"},
+			},
+			"plan": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"service_plan"},
 			},
 			"space": &schema.Schema{
 				Type:     schema.TypeString,
@@ -60,6 +83,16 @@ func resourceServiceInstance() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			"stuck_threshold": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+			"prevent_destroy_in_cf": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
@@ -76,9 +109,13 @@ func resourceServiceInstanceCreate(d *schema.ResourceData, meta interface{}) (er
 		tags   []string
 		params map[string]interface{}
 	)
-	name := d.Get("name").(string)
-	servicePlan := d.Get("service_plan").(string)
+	name := appliedName(d, session, d.Get("name").(string))
 	space := d.Get("space").(string)
+	servicePlan, err := serviceInstancePlanGUID(d, session.ServiceManager())
+	if err != nil {
+		return err
+	}
+	d.Set("service_plan", servicePlan)
 	jsonParameters := d.Get("json_params").(string)
 
 	for _, v := range d.Get("tags").([]interface{}) {
@@ -93,27 +130,44 @@ func resourceServiceInstanceCreate(d *schema.ResourceData, meta interface{}) (er
 
 	sm := session.ServiceManager()
 
+	release, err := acquireServiceBrokerSlot(session, servicePlan)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	if id, err = sm.CreateServiceInstance(name, servicePlan, space, params, tags); err != nil {
 		return err
 	}
+	// delete the instance again if waiting on the asynchronous provisioning
+	// fails, so a broken apply does not leave a half-provisioned instance
+	cleanup := newCleanupRegistry(session)
+	defer cleanup.runOnError(&err)
+	cleanup.register(fmt.Sprintf("service instance '%s'", id), func() error {
+		return sm.DeleteServiceInstance(id, true)
+	})
 	stateConf := &resource.StateChangeConf{
 		Pending:        resourceServiceInstancePendingStates,
 		Target:         resourceServiceInstanceSuccessStates,
-		Refresh:        resourceServiceInstanceStateFunc(id, "create", meta),
-		Timeout:        d.Timeout(schema.TimeoutCreate),
+		Refresh:        resourceServiceInstanceStateFunc(id, "create", meta, serviceInstanceStuckThreshold(d)),
+		Timeout:        serviceInstanceTimeout(d, session, schema.TimeoutCreate),
 		PollInterval:   30 * time.Second,
 		Delay:          5 * time.Second,
 		NotFoundChecks: 6, // if the CF object for the instance isn't at least present after 3 minutes, it's probably not coming
 	}
 
 	// Wait, catching any errors
-	if _, err = stateConf.WaitForState(); err != nil {
+	if _, err = waitForStateWithBackoff(stateConf); err != nil {
 		return err
 	}
 
 	session.Log.DebugMessage("New Service Instance : %# v", id)
 
 	d.SetId(id)
+	annotateManagedResource(session, "service_instances", id)
+	if d.Get("prevent_destroy_in_cf").(bool) {
+		setPreventDestroyAnnotation(session, "service_instances", id, true)
+	}
 
 	return nil
 }
@@ -138,7 +192,7 @@ func resourceServiceInstanceRead(d *schema.ResourceData, meta interface{}) (err
 		return err
 	}
 
-	d.Set("name", serviceInstance.Name)
+	d.Set("name", strippedName(d, session, serviceInstance.Name))
 	d.Set("service_plan", serviceInstance.ServicePlanGUID)
 	d.Set("space", serviceInstance.SpaceGUID)
 
@@ -163,6 +217,12 @@ func resourceServiceInstanceUpdate(d *schema.ResourceData, meta interface{}) (er
 	if session == nil {
 		return fmt.Errorf("client is nil")
 	}
+	if err = validateManagedResource(session, "service_instances", d.Id()); err != nil {
+		return err
+	}
+	if d.HasChange("prevent_destroy_in_cf") {
+		setPreventDestroyAnnotation(session, "service_instances", d.Id(), d.Get("prevent_destroy_in_cf").(bool))
+	}
 	sm := session.ServiceManager()
 
 	session.Log.DebugMessage("begin resourceServiceInstanceUpdate")
@@ -181,8 +241,27 @@ func resourceServiceInstanceUpdate(d *schema.ResourceData, meta interface{}) (er
 	)
 
 	id = d.Id()
-	name = d.Get("name").(string)
-	servicePlan := d.Get("service_plan").(string)
+	name = appliedName(d, session, d.Get("name").(string))
+
+	// a pure rename is synchronous in the Cloud Controller, so skip the
+	// full update with its asynchronous poll loop for it
+	if d.HasChange("name") &&
+		!d.HasChange("service_plan") && !d.HasChange("service") && !d.HasChange("plan") &&
+		!d.HasChange("json_params") && !d.HasChange("tags") {
+
+		session.Log.DebugMessage("Renaming service instance %s to '%s'", id, name)
+		if err = sm.RenameServiceInstance(id, name); err != nil {
+			return err
+		}
+		d.Partial(false)
+		return nil
+	}
+
+	servicePlan, err := serviceInstancePlanGUID(d, sm)
+	if err != nil {
+		return err
+	}
+	d.Set("service_plan", servicePlan)
 	jsonParameters := d.Get("json_params").(string)
 
 	if len(jsonParameters) > 0 {
@@ -195,6 +274,12 @@ func resourceServiceInstanceUpdate(d *schema.ResourceData, meta interface{}) (er
 		tags = append(tags, v.(string))
 	}
 
+	release, err := acquireServiceBrokerSlot(session, servicePlan)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	if _, err = sm.UpdateServiceInstance(id, name, servicePlan, params, tags); err != nil {
 		return err
 	}
@@ -202,14 +287,14 @@ func resourceServiceInstanceUpdate(d *schema.ResourceData, meta interface{}) (er
 	stateConf := &resource.StateChangeConf{
 		Pending:        resourceServiceInstancePendingStates,
 		Target:         resourceServiceInstanceSuccessStates,
-		Refresh:        resourceServiceInstanceStateFunc(id, "update", meta),
-		Timeout:        d.Timeout(schema.TimeoutUpdate),
+		Refresh:        resourceServiceInstanceStateFunc(id, "update", meta, serviceInstanceStuckThreshold(d)),
+		Timeout:        serviceInstanceTimeout(d, session, schema.TimeoutUpdate),
 		PollInterval:   30 * time.Second,
 		Delay:          5 * time.Second,
 		NotFoundChecks: 3, // if we don't find the service instance in CF during an update, something is definately wrong
 	}
 	// Wait, catching any errors
-	if _, err = stateConf.WaitForState(); err != nil {
+	if _, err = waitForStateWithBackoff(stateConf); err != nil {
 		return err
 	}
 
@@ -228,22 +313,47 @@ func resourceServiceInstanceDelete(d *schema.ResourceData, meta interface{}) (er
 	}
 	session.Log.DebugMessage("begin resourceServiceInstanceDelete")
 
+	if err = validateDestroyAllowed(d, "service_instances"); err != nil {
+		return err
+	}
+	if err = validateManagedResource(session, "service_instances", id); err != nil {
+		return err
+	}
+
 	sm := session.ServiceManager()
 	recursiveDelete := d.Get("recursive_delete").(bool)
 
-	if err = sm.DeleteServiceInstance(id, recursiveDelete); err != nil {
+	release, err := acquireServiceBrokerSlot(session, d.Get("service_plan").(string))
+	if err != nil {
 		return err
 	}
+	defer release()
+
+	if err = sm.DeleteServiceInstance(id, recursiveDelete); err != nil {
+		if !isServiceInstanceOperationInProgressError(err) {
+			return err
+		}
+		// an in-flight create or update blocks deletion; wait for it to
+		// settle and retry instead of requiring manual CLI intervention
+		session.Log.DebugMessage(
+			"Service instance '%s' has an operation in progress, waiting for it to settle before deleting.", id)
+		if err = waitForServiceInstanceOperation(d, session, id); err != nil {
+			return err
+		}
+		if err = sm.DeleteServiceInstance(id, recursiveDelete); err != nil {
+			return err
+		}
+	}
 	stateConf := &resource.StateChangeConf{
 		Pending:      resourceServiceInstancePendingStates,
 		Target:       []string{}, // in case of deletion, the state manager checks for nil object result and a 0 length list of target states
-		Refresh:      resourceServiceInstanceStateFunc(id, "delete", meta),
-		Timeout:      d.Timeout(schema.TimeoutDelete),
+		Refresh:      resourceServiceInstanceStateFunc(id, "delete", meta, serviceInstanceStuckThreshold(d)),
+		Timeout:      serviceInstanceTimeout(d, session, schema.TimeoutDelete),
 		PollInterval: 30 * time.Second,
 		Delay:        5 * time.Second,
 	}
 	// Wait, catching any errors
-	if _, err = stateConf.WaitForState(); err != nil {
+	if _, err = waitForStateWithBackoff(stateConf); err != nil {
 		return err
 	}
 
@@ -252,6 +362,39 @@ func resourceServiceInstanceDelete(d *schema.ResourceData, meta interface{}) (er
 	return nil
 }
 
+// isServiceInstanceOperationInProgressError - true when the Cloud Controller
+// refused to act on the instance because an asynchronous operation is still
+// in flight
+func isServiceInstanceOperationInProgressError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "asyncserviceinstanceoperationinprogress") ||
+		strings.Contains(msg, "operation in progress")
+}
+
+// waitForServiceInstanceOperation - waits until the instance's in-flight
+// operation finishes, successfully or not, so a delete can be issued
+func waitForServiceInstanceOperation(d *schema.ResourceData, session *cfapi.Session, id string) error {
+
+	sm := session.ServiceManager()
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"in progress"},
+		Target:  []string{"succeeded", "failed"},
+		Refresh: func() (interface{}, string, error) {
+			instance, err := sm.ReadServiceInstance(id)
+			if err != nil {
+				return nil, "", err
+			}
+			state, _ := instance.LastOperation["state"].(string)
+			return instance, state, nil
+		},
+		Timeout:      serviceInstanceTimeout(d, session, schema.TimeoutDelete),
+		PollInterval: 30 * time.Second,
+		Delay:        5 * time.Second,
+	}
+	_, err := waitForStateWithBackoff(stateConf)
+	return err
+}
+
 func resourceServiceInstanceImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	session := meta.(*cfapi.Session)
 
@@ -261,10 +404,23 @@ func resourceServiceInstanceImport(d *schema.ResourceData, meta interface{}) ([]
 
 	sm := session.ServiceManager()
 
-	serviceinstance, err := sm.ReadServiceInstance(d.Id())
+	var (
+		serviceinstance cfapi.CCServiceInstance
+		err             error
+	)
 
-	if err != nil {
-		return nil, err
+	if idParts := strings.Split(d.Id(), "/"); len(idParts) == 2 {
+		// import by "<space guid>/<instance name>"
+		serviceinstance, err = sm.FindServiceInstance(idParts[1], idParts[0])
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(serviceinstance.ID)
+	} else {
+		serviceinstance, err = sm.ReadServiceInstance(d.Id())
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	d.Set("name", serviceinstance.Name)
@@ -278,7 +434,13 @@ func resourceServiceInstanceImport(d *schema.ResourceData, meta interface{}) ([]
 	return ImportStatePassthrough(d, meta)
 }
 
-func resourceServiceInstanceStateFunc(serviceInstanceID string, operationType string, meta interface{}) resource.StateRefreshFunc {
+func resourceServiceInstanceStateFunc(serviceInstanceID string, operationType string, meta interface{}, stuckThreshold time.Duration) resource.StateRefreshFunc {
+
+	var (
+		lastUpdatedAt string
+		lastMovement  time.Time
+	)
+
 	return func() (interface{}, string, error) {
 		session := meta.(*cfapi.Session)
 		sm := session.ServiceManager()
@@ -303,6 +465,19 @@ func resourceServiceInstanceStateFunc(serviceInstanceID string, operationType st
 			case "failed":
 				session.Log.DebugMessage("service instance with guid=%s async provisioning has failed", serviceInstanceID)
 				return nil, state, fmt.Errorf("%s", serviceInstance.LastOperation["description"])
+			case "in progress":
+				if stuckThreshold > 0 {
+					updatedAt := fmt.Sprintf("%s", serviceInstance.LastOperation["updated_at"])
+					if updatedAt != lastUpdatedAt {
+						lastUpdatedAt = updatedAt
+						lastMovement = time.Now()
+					} else if time.Since(lastMovement) > stuckThreshold {
+						return nil, state, fmt.Errorf(
+							"service instance %s operation received no broker update for %s "+
+								"(last_operation updated_at stuck at %s), broker reports: %s",
+							operationType, stuckThreshold, updatedAt, serviceInstance.LastOperation["description"])
+					}
+				}
 			}
 			return serviceInstance, state, nil
 		}
@@ -311,6 +486,45 @@ func resourceServiceInstanceStateFunc(serviceInstanceID string, operationType st
 	}
 }
 
+// serviceInstanceTimeout - resolves the operation timeout falling back to the
+// provider wide service default when the resource keeps the schema default
+func serviceInstanceTimeout(d *schema.ResourceData, session *cfapi.Session, key string) time.Duration {
+	timeout := d.Timeout(key)
+	if timeout == defaultServiceInstanceTimeout && session.DefaultServiceTimeout > 0 {
+		timeout = time.Second * time.Duration(session.DefaultServiceTimeout)
+	}
+	return timeout
+}
+
+// serviceInstanceStuckThreshold - resolves the optional threshold after which
+// an "in progress" operation with no updated_at movement is failed early
+func serviceInstanceStuckThreshold(d *schema.ResourceData) time.Duration {
+	return time.Second * time.Duration(d.Get("stuck_threshold").(int))
+}
+
+// serviceInstancePlanGUID - resolves the service plan GUID either from the
+// 'service_plan' attribute or from the 'service' offering and 'plan' names
+func serviceInstancePlanGUID(d *schema.ResourceData, sm *cfapi.ServiceManager) (planGUID string, err error) {
+
+	if v, ok := d.GetOk("service"); ok {
+		plan, planOk := d.GetOk("plan")
+		if !planOk {
+			return "", fmt.Errorf("the 'plan' attribute needs to be provided along with 'service'")
+		}
+		offering, err := sm.FindServiceByName(v.(string))
+		if err != nil {
+			return "", err
+		}
+		return sm.FindServicePlanID(offering.GUID, plan.(string))
+	}
+
+	v, ok := d.GetOk("service_plan")
+	if !ok {
+		return "", fmt.Errorf("one of 'service_plan' or 'service' and 'plan' needs to be provided")
+	}
+	return v.(string), nil
+}
+
 var resourceServiceInstancePendingStates = []string{
 	"in progress",
 }
@@ -318,3 +532,119 @@ var resourceServiceInstancePendingStates = []string{
 var resourceServiceInstanceSuccessStates = []string{
 	"succeeded",
 }
+
+// acquireServiceBrokerSlot - reserves a concurrency slot with the broker
+// serving the given service plan, blocking while the broker is at the ceiling
+// configured via the provider's 'service_broker_concurrency' map; the
+// returned release function must always be called
+func acquireServiceBrokerSlot(session *cfapi.Session, servicePlanID string) (release func(), err error) {
+
+	if session.ServiceBrokerLimiter == nil {
+		return func() {}, nil
+	}
+	broker, err := session.ServiceManager().ServiceBrokerNameForPlan(servicePlanID)
+	if err != nil {
+		return nil, err
+	}
+	return session.ServiceBrokerLimiter.Acquire(broker), nil
+}
+
+// acquireServiceBrokerSlotForInstance - as acquireServiceBrokerSlot but keyed
+// by an existing service instance, for bindings and keys
+func acquireServiceBrokerSlotForInstance(session *cfapi.Session, serviceInstanceID string) (release func(), err error) {
+
+	if session.ServiceBrokerLimiter == nil {
+		return func() {}, nil
+	}
+	serviceInstance, err := session.ServiceManager().ReadServiceInstance(serviceInstanceID)
+	if err != nil {
+		return nil, err
+	}
+	return acquireServiceBrokerSlot(session, serviceInstance.ServicePlanGUID)
+}
+
+// resourceServiceInstanceCustomizeDiff - validates 'json_params' against the
+// parameter schema the broker publishes for the plan (OSB schemas), so field
+// level mistakes fail the plan instead of surfacing as broker 400s during
+// the apply, and re-resolves the plan GUID when the plan is referenced by
+// offering and plan name
+func resourceServiceInstanceCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) (err error) {
+
+	session, ok := meta.(*cfapi.Session)
+	if !ok || session == nil {
+		return nil
+	}
+
+	// plans recreated by the broker under a new GUID surface as a diff on
+	// the computed 'service_plan' attribute, leaving the configured 'plan'
+	// name untouched; the update then moves the instance to the new GUID
+	if offeringName, offeringOk := diff.GetOk("service"); offeringOk && len(diff.Id()) > 0 {
+		if planName, planOk := diff.GetOk("plan"); planOk {
+			sm := session.ServiceManager()
+			if offering, err := sm.FindServiceByName(offeringName.(string)); err == nil {
+				planID, err := sm.FindServicePlanID(offering.GUID, planName.(string))
+				if err == nil && planID != diff.Get("service_plan").(string) {
+					if err = diff.SetNew("service_plan", planID); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	jsonParameters := diff.Get("json_params").(string)
+	if len(jsonParameters) == 0 {
+		return nil
+	}
+	var params map[string]interface{}
+	if err = json.Unmarshal([]byte(jsonParameters), &params); err != nil {
+		return fmt.Errorf("'json_params' is not valid JSON: %s", err.Error())
+	}
+
+	planID := diff.Get("service_plan").(string)
+	if len(planID) == 0 {
+		// the plan is referenced by name or created in the same plan, so its
+		// schemas cannot be inspected yet
+		return nil
+	}
+	plan, err := session.ServiceManager().ReadServicePlan(planID)
+	if err != nil {
+		session.Log.DebugMessage("Skipping json_params check, unable to read plan %s: %s", planID, err.Error())
+		return nil
+	}
+
+	operation := "create"
+	if len(diff.Id()) > 0 {
+		operation = "update"
+	}
+	parameterSchema := servicePlanParameterSchema(plan, operation)
+	if parameterSchema == nil {
+		return nil
+	}
+
+	if errs := validateAgainstJSONSchema(params, parameterSchema, ""); len(errs) > 0 {
+		return fmt.Errorf(
+			"'json_params' does not conform to the schema the broker publishes for this plan:\n  %s",
+			strings.Join(errs, "\n  "))
+	}
+	return nil
+}
+
+// servicePlanParameterSchema - digs the create or update parameters schema
+// out of the plan's OSB schemas; nil when the broker does not publish one
+func servicePlanParameterSchema(plan cfapi.CCServicePlan, operation string) map[string]interface{} {
+
+	serviceInstance, ok := plan.Schemas["service_instance"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	op, ok := serviceInstance[operation].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	parameters, ok := op["parameters"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return parameters
+}
@@ -0,0 +1,151 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func resourceServiceInstanceCredentialRotation() *schema.Resource {
+
+	return &schema.Resource{
+
+		Create: resourceServiceInstanceCredentialRotationCreate,
+		Read:   resourceServiceInstanceCredentialRotationRead,
+		Update: resourceServiceInstanceCredentialRotationUpdate,
+		Delete: resourceServiceInstanceCredentialRotationDelete,
+
+		Schema: map[string]*schema.Schema{
+
+			"service_instance": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name_prefix": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"rotation_keeper": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"params": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"generation": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"credentials": &schema.Schema{
+				Type:      schema.TypeMap,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceServiceInstanceCredentialRotationCreate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	serviceKey, err := createRotationServiceKey(d, session, 1)
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", serviceKey.Name)
+	d.Set("generation", 1)
+	d.Set("credentials", normalizeMap(serviceKey.Credentials, make(map[string]interface{}), "", "_"))
+	d.SetId(serviceKey.ID)
+	return nil
+}
+
+func resourceServiceInstanceCredentialRotationRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+	session.Log.DebugMessage("Reading rotated Service Key with ID: %s", d.Id())
+
+	sm := session.ServiceManager()
+	var serviceKey cfapi.CCServiceKey
+
+	if serviceKey, err = sm.ReadServiceKey(d.Id()); err != nil {
+		if strings.Contains(err.Error(), "status code: 404") {
+			d.SetId("")
+			err = nil
+		}
+		return err
+	}
+	d.Set("name", serviceKey.Name)
+	d.Set("service_instance", serviceKey.ServiceGUID)
+	d.Set("credentials", normalizeMap(serviceKey.Credentials, make(map[string]interface{}), "", "_"))
+	return nil
+}
+
+func resourceServiceInstanceCredentialRotationUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	// any change rotates the credentials; the new service key is created
+	// before the previous one is destroyed so consumers can be switched
+	// over without a window where no valid credentials exist
+	generation := d.Get("generation").(int) + 1
+	oldKeyID := d.Id()
+
+	serviceKey, err := createRotationServiceKey(d, session, generation)
+	if err != nil {
+		return err
+	}
+
+	if err = session.ServiceManager().DeleteServiceKey(oldKeyID); err != nil {
+		session.Log.DebugMessage(
+			"unable to delete superseded service key %s : %s", oldKeyID, err.Error())
+	}
+
+	d.Set("name", serviceKey.Name)
+	d.Set("generation", generation)
+	d.Set("credentials", normalizeMap(serviceKey.Credentials, make(map[string]interface{}), "", "_"))
+	d.SetId(serviceKey.ID)
+	return nil
+}
+
+func resourceServiceInstanceCredentialRotationDelete(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+	return session.ServiceManager().DeleteServiceKey(d.Id())
+}
+
+// createRotationServiceKey - creates the service key for the given rotation generation
+func createRotationServiceKey(d *schema.ResourceData, session *cfapi.Session, generation int) (serviceKey cfapi.CCServiceKey, err error) {
+
+	name := fmt.Sprintf("%s-%d", d.Get("name_prefix").(string), generation)
+	serviceInstance := d.Get("service_instance").(string)
+	params := d.Get("params").(map[string]interface{})
+
+	if serviceKey, err = session.ServiceManager().CreateServiceKey(name, serviceInstance, params); err != nil {
+		return cfapi.CCServiceKey{}, err
+	}
+	session.Log.DebugMessage("Created rotated Service Key: %# v", serviceKey)
+	return serviceKey, nil
+}
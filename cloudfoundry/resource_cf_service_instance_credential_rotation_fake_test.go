@@ -0,0 +1,81 @@
+package cloudfoundry
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestServiceInstanceCredentialRotation_fake(t *testing.T) {
+
+	f, session := newFakeSession(t)
+	defer f.Close()
+
+	keyResource := func(guid, name, user string) map[string]interface{} {
+		return map[string]interface{}{
+			"metadata": map[string]interface{}{"guid": guid},
+			"entity": map[string]interface{}{
+				"name":                  name,
+				"service_instance_guid": "si-one",
+				"credentials":           map[string]interface{}{"username": user},
+			},
+		}
+	}
+	f.Stub("POST", "/v2/service_keys", 201, keyResource("key-one", "creds-1", "user-1"))
+
+	d := schema.TestResourceDataRaw(t, resourceServiceInstanceCredentialRotation().Schema, map[string]interface{}{
+		"service_instance": "si-one",
+		"name_prefix":      "creds",
+		"rotation_keeper":  "2026-01",
+	})
+	if err := resourceServiceInstanceCredentialRotationCreate(d, session); err != nil {
+		t.Fatalf("create returned error: %s", err)
+	}
+	if d.Id() != "key-one" {
+		t.Errorf("expected the service key GUID as ID, got '%s'", d.Id())
+	}
+	if v := d.Get("generation").(int); v != 1 {
+		t.Errorf("expected generation 1, got %d", v)
+	}
+	if v := d.Get("name").(string); v != "creds-1" {
+		t.Errorf("expected the generation suffixed key name, got '%s'", v)
+	}
+
+	// a rotation creates the next generation before the superseded key is
+	// removed; a failing removal of the old key does not fail the rotation
+	f.Stub("POST", "/v2/service_keys", 201, keyResource("key-two", "creds-2", "user-2"))
+	if err := resourceServiceInstanceCredentialRotationUpdate(d, session); err != nil {
+		t.Fatalf("update returned error: %s", err)
+	}
+	if d.Id() != "key-two" {
+		t.Errorf("expected the rotated key GUID as ID, got '%s'", d.Id())
+	}
+	if v := d.Get("generation").(int); v != 2 {
+		t.Errorf("expected generation 2, got %d", v)
+	}
+	if v := d.Get("credentials").(map[string]interface{})["username"]; v != "user-2" {
+		t.Errorf("expected the rotated credentials, got '%v'", v)
+	}
+
+	f.Stub("GET", "/v2/service_keys/key-two", 200, keyResource("key-two", "creds-2", "user-2"))
+	if err := resourceServiceInstanceCredentialRotationRead(d, session); err != nil {
+		t.Fatalf("read returned error: %s", err)
+	}
+	if v := d.Get("service_instance").(string); v != "si-one" {
+		t.Errorf("expected the service instance GUID to be read back, got '%s'", v)
+	}
+
+	f.Stub("DELETE", "/v2/service_keys/key-two", 204, nil)
+	if err := resourceServiceInstanceCredentialRotationDelete(d, session); err != nil {
+		t.Errorf("delete returned error: %s", err)
+	}
+
+	// a key deleted out of band clears the state
+	d.SetId("key-gone")
+	if err := resourceServiceInstanceCredentialRotationRead(d, session); err != nil {
+		t.Fatalf("read returned error: %s", err)
+	}
+	if len(d.Id()) != 0 {
+		t.Error("expected the ID to be cleared for a removed service key")
+	}
+}
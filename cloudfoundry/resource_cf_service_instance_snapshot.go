@@ -0,0 +1,174 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func resourceServiceInstanceSnapshot() *schema.Resource {
+
+	return &schema.Resource{
+
+		Create: resourceServiceInstanceSnapshotCreate,
+		Read:   resourceServiceInstanceSnapshotRead,
+		Update: resourceServiceInstanceSnapshotUpdate,
+		Delete: resourceServiceInstanceSnapshotDelete,
+
+		Schema: map[string]*schema.Schema{
+
+			"service_instance": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"snapshot_keeper": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"params": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+			"broker_username": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"broker_password": &schema.Schema{
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(15 * time.Minute),
+			Update: schema.DefaultTimeout(15 * time.Minute),
+		},
+	}
+}
+
+func resourceServiceInstanceSnapshotCreate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+	return triggerServiceInstanceSnapshot(d, session, schema.TimeoutCreate)
+}
+
+func resourceServiceInstanceSnapshotRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	snapshot, err := session.ReadServiceInstanceSnapshot(
+		d.Get("service_instance").(string), d.Id(),
+		d.Get("broker_username").(string), d.Get("broker_password").(string))
+	if err != nil {
+		if strings.Contains(err.Error(), "status code: 404") ||
+			strings.Contains(err.Error(), "status code: 410") {
+			d.SetId("")
+			err = nil
+		}
+		return err
+	}
+	d.Set("state", snapshot.State)
+	d.Set("created_at", snapshot.CreatedAt)
+	return nil
+}
+
+func resourceServiceInstanceSnapshotUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+	if !d.HasChange("snapshot_keeper") && !d.HasChange("params") {
+		return nil
+	}
+	// any keeper or parameter change triggers a fresh snapshot; the previous
+	// one is left with the broker so its retention policy applies
+	return triggerServiceInstanceSnapshot(d, session, schema.TimeoutUpdate)
+}
+
+func resourceServiceInstanceSnapshotDelete(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	if err = session.DeleteServiceInstanceSnapshot(
+		d.Get("service_instance").(string), d.Id(),
+		d.Get("broker_username").(string), d.Get("broker_password").(string)); err != nil &&
+		!strings.Contains(err.Error(), "status code: 404") &&
+		!strings.Contains(err.Error(), "status code: 410") {
+		return err
+	}
+	return nil
+}
+
+// triggerServiceInstanceSnapshot - triggers a snapshot on the broker and
+// polls it until the broker reports it succeeded or failed
+func triggerServiceInstanceSnapshot(d *schema.ResourceData, session *cfapi.Session, timeoutKey string) (err error) {
+
+	serviceInstance := d.Get("service_instance").(string)
+	username := d.Get("broker_username").(string)
+	password := d.Get("broker_password").(string)
+
+	release, err := acquireServiceBrokerSlotForInstance(session, serviceInstance)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	snapshot, err := session.CreateServiceInstanceSnapshot(
+		serviceInstance, username, password, d.Get("params").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+	session.Log.DebugMessage("Triggered snapshot '%s' of service instance '%s'.", snapshot.ID, serviceInstance)
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"in progress", ""},
+		Target:  []string{"succeeded"},
+		Refresh: func() (interface{}, string, error) {
+			s, err := session.ReadServiceInstanceSnapshot(serviceInstance, snapshot.ID, username, password)
+			if err != nil {
+				return nil, "", err
+			}
+			if s.State == "failed" {
+				return nil, "", fmt.Errorf(
+					"snapshot '%s' of service instance '%s' failed: %s", snapshot.ID, serviceInstance, s.Description)
+			}
+			return s, s.State, nil
+		},
+		Timeout:      d.Timeout(timeoutKey),
+		PollInterval: 30 * time.Second,
+		Delay:        5 * time.Second,
+	}
+	final, err := waitForStateWithBackoff(stateConf)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(snapshot.ID)
+	d.Set("state", final.(cfapi.ServiceInstanceSnapshot).State)
+	d.Set("created_at", final.(cfapi.ServiceInstanceSnapshot).CreatedAt)
+	return nil
+}
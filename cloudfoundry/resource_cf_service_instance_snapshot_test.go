@@ -0,0 +1,109 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+const serviceInstanceSnapshotResourceCommon = `
+
+data "cloudfoundry_org" "org" {
+    name = "pcfdev-org"
+}
+data "cloudfoundry_space" "space" {
+    name = "pcfdev-space"
+	org = "${data.cloudfoundry_org.org.id}"
+}
+data "cloudfoundry_service" "mysql" {
+    name = "p-mysql"
+}
+
+resource "cloudfoundry_service_instance" "mysql" {
+	name = "mysql-snapshot-test"
+    space = "${data.cloudfoundry_space.space.id}"
+    service_plan = "${data.cloudfoundry_service.mysql.service_plans["512mb"]}"
+}
+`
+
+const serviceInstanceSnapshotResource = serviceInstanceSnapshotResourceCommon + `
+
+resource "cloudfoundry_service_instance_snapshot" "backup" {
+	service_instance = "${cloudfoundry_service_instance.mysql.id}"
+	snapshot_keeper = "release-1"
+}
+`
+
+const serviceInstanceSnapshotResourceUpdate = serviceInstanceSnapshotResourceCommon + `
+
+resource "cloudfoundry_service_instance_snapshot" "backup" {
+	service_instance = "${cloudfoundry_service_instance.mysql.id}"
+	snapshot_keeper = "release-2"
+}
+`
+
+func TestAccServiceInstanceSnapshot_normal(t *testing.T) {
+
+	ref := "cloudfoundry_service_instance_snapshot.backup"
+
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+
+				resource.TestStep{
+					Config: serviceInstanceSnapshotResource,
+					Check: resource.ComposeTestCheckFunc(
+						checkServiceInstanceSnapshotExists(ref),
+						resource.TestCheckResourceAttr(
+							ref, "snapshot_keeper", "release-1"),
+						resource.TestCheckResourceAttr(
+							ref, "state", "succeeded"),
+					),
+				},
+				resource.TestStep{
+					// a keeper change triggers a fresh snapshot
+					Config: serviceInstanceSnapshotResourceUpdate,
+					Check: resource.ComposeTestCheckFunc(
+						checkServiceInstanceSnapshotExists(ref),
+						resource.TestCheckResourceAttr(
+							ref, "snapshot_keeper", "release-2"),
+						resource.TestCheckResourceAttr(
+							ref, "state", "succeeded"),
+					),
+				},
+			},
+		})
+}
+
+func checkServiceInstanceSnapshotExists(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) (err error) {
+
+		session := testAccProvider.Meta().(*cfapi.Session)
+
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("snapshot '%s' not found in terraform state", resource)
+		}
+
+		session.Log.DebugMessage(
+			"terraform state for resource '%s': %# v",
+			resource, rs)
+
+		id := rs.Primary.ID
+		attributes := rs.Primary.Attributes
+
+		snapshot, err := session.ReadServiceInstanceSnapshot(
+			attributes["service_instance"], id,
+			attributes["broker_username"], attributes["broker_password"])
+		if err != nil {
+			return err
+		}
+		return assertEquals(attributes, "state", snapshot.State)
+	}
+}
@@ -59,6 +59,12 @@ func resourceServiceKeyCreate(d *schema.ResourceData, meta interface{}) (err err
 	sm := session.ServiceManager()
 	var serviceKey cfapi.CCServiceKey
 
+	release, err := acquireServiceBrokerSlotForInstance(session, serviceInstance)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	if serviceKey, err = sm.CreateServiceKey(name, serviceInstance, params); err != nil {
 		return err
 	}
@@ -0,0 +1,88 @@
+package cloudfoundry
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func resourceSpaceFeature() *schema.Resource {
+
+	return &schema.Resource{
+
+		Create: resourceSpaceFeatureCreate,
+		Read:   resourceSpaceFeatureRead,
+		Update: resourceSpaceFeatureUpdate,
+		Delete: resourceSpaceFeatureDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				d.Set("space", d.Id())
+				return ImportStatePassthrough(d, meta)
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			"space": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"allow_ssh": &schema.Schema{
+				Type:     schema.TypeBool,
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceSpaceFeatureCreate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	if err = resourceSpaceFeatureUpdate(d, meta); err != nil {
+		return err
+	}
+	d.SetId(d.Get("space").(string))
+	return nil
+}
+
+func resourceSpaceFeatureRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	space, err := session.SpaceManager().ReadSpace(d.Get("space").(string))
+	if err != nil {
+		return err
+	}
+	// surfaces drift when the flag was toggled via the CLI
+	d.Set("allow_ssh", space.AllowSSH)
+	return nil
+}
+
+func resourceSpaceFeatureUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	sm := session.SpaceManager()
+
+	space, err := sm.ReadSpace(d.Get("space").(string))
+	if err != nil {
+		return err
+	}
+	space.AllowSSH = d.Get("allow_ssh").(bool)
+
+	return sm.UpdateSpace(space, nil)
+}
+
+func resourceSpaceFeatureDelete(d *schema.ResourceData, meta interface{}) (err error) {
+
+	// the feature flag is left as-is; only the state entry is removed
+	return nil
+}
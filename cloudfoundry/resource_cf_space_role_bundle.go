@@ -0,0 +1,212 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func resourceSpaceRoleBundle() *schema.Resource {
+
+	return &schema.Resource{
+
+		Create: resourceSpaceRoleBundleCreate,
+		Read:   resourceSpaceRoleBundleRead,
+		Update: resourceSpaceRoleBundleUpdate,
+		Delete: resourceSpaceRoleBundleDelete,
+
+		Schema: map[string]*schema.Schema{
+
+			"space": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"roles": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"managers", "developers", "auditors"}, false),
+				},
+			},
+			"users": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      resourceStringHash,
+			},
+		},
+	}
+}
+
+func resourceSpaceRoleBundleCreate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	space := d.Get("space").(string)
+	roles := spaceRoleBundleRoles(d)
+
+	var users []string
+	for _, v := range d.Get("users").(*schema.Set).List() {
+		users = append(users, v.(string))
+	}
+
+	if err = spaceRoleBundleAssign(session, space, roles, users); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", space, strings.Join(spaceRoleBundleRoleNames(roles), "+")))
+	return nil
+}
+
+func resourceSpaceRoleBundleRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	sm := session.SpaceManager()
+	space := d.Get("space").(string)
+
+	// users that hold every role of the bundle; anyone who lost a role is
+	// dropped from state so the next apply re-assigns the full bundle
+	holders := make(map[string]int)
+	for _, role := range spaceRoleBundleRoles(d) {
+		userIDs, err := sm.ListUsers(space, role)
+		if err != nil {
+			if strings.Contains(err.Error(), "status code: 404") {
+				d.SetId("")
+				return nil
+			}
+			return err
+		}
+		for _, u := range userIDs {
+			holders[u.(string)]++
+		}
+	}
+
+	var users []interface{}
+	roleCount := len(spaceRoleBundleRoles(d))
+	for _, v := range d.Get("users").(*schema.Set).List() {
+		if holders[v.(string)] == roleCount {
+			users = append(users, v)
+		}
+	}
+	d.Set("users", schema.NewSet(resourceStringHash, users))
+
+	return nil
+}
+
+func resourceSpaceRoleBundleUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	sm := session.SpaceManager()
+	space := d.Get("space").(string)
+	roles := spaceRoleBundleRoles(d)
+
+	old, new := d.GetChange("users")
+	remove, add := getListChanges(old, new)
+
+	for _, uid := range remove {
+		for _, role := range roles {
+			if err = sm.RemoveUser(space, uid, role); err != nil {
+				return err
+			}
+		}
+	}
+	return spaceRoleBundleAssign(session, space, roles, add)
+}
+
+func resourceSpaceRoleBundleDelete(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	sm := session.SpaceManager()
+	space := d.Get("space").(string)
+
+	for _, v := range d.Get("users").(*schema.Set).List() {
+		for _, role := range spaceRoleBundleRoles(d) {
+			if err = sm.RemoveUser(space, v.(string), role); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// spaceRoleBundleAssign - assigns all roles of the bundle to the given
+// users, rolling the batch back when one of the assignments fails so the
+// bundle is applied all-or-nothing
+func spaceRoleBundleAssign(session *cfapi.Session, space string, roles []cfapi.SpaceRole, users []string) (err error) {
+
+	sm := session.SpaceManager()
+	om := session.OrgManager()
+
+	s, err := sm.ReadSpace(space)
+	if err != nil {
+		return err
+	}
+
+	type assignment struct {
+		user string
+		role cfapi.SpaceRole
+	}
+	var applied []assignment
+
+	defer func() {
+		if err == nil {
+			return
+		}
+		for _, a := range applied {
+			if e := sm.RemoveUser(space, a.user, a.role); e != nil {
+				session.Log.DebugMessage(
+					"unable to roll back role '%s' of user '%s' in space '%s' : %s", a.role, a.user, space, e.Error())
+			}
+		}
+	}()
+
+	for _, uid := range users {
+		if err = om.AddUser(s.OrgGUID, uid, cfapi.OrgRoleMember); err != nil {
+			return err
+		}
+		for _, role := range roles {
+			if err = sm.AddUser(space, uid, role); err != nil {
+				return err
+			}
+			applied = append(applied, assignment{user: uid, role: role})
+		}
+	}
+	return nil
+}
+
+func spaceRoleBundleRoles(d *schema.ResourceData) (roles []cfapi.SpaceRole) {
+	for _, v := range d.Get("roles").([]interface{}) {
+		roles = append(roles, cfapi.SpaceRole(v.(string)))
+	}
+	return roles
+}
+
+func spaceRoleBundleRoleNames(roles []cfapi.SpaceRole) (names []string) {
+	for _, r := range roles {
+		names = append(names, string(r))
+	}
+	return names
+}
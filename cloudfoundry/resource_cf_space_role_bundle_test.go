@@ -0,0 +1,124 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+const spaceRoleBundleResourceCommon = `
+
+data "cloudfoundry_org" "org" {
+    name = "pcfdev-org"
+}
+data "cloudfoundry_space" "space" {
+    name = "pcfdev-space"
+	org = "${data.cloudfoundry_org.org.id}"
+}
+
+resource "cloudfoundry_user" "dev1" {
+    name = "bundle-dev1@acme.com"
+}
+resource "cloudfoundry_user" "dev2" {
+    name = "bundle-dev2@acme.com"
+}
+`
+
+const spaceRoleBundleResource = spaceRoleBundleResourceCommon + `
+
+resource "cloudfoundry_space_role_bundle" "developers" {
+	space = "${data.cloudfoundry_space.space.id}"
+	roles = [ "developers", "auditors" ]
+	users = [ "${cloudfoundry_user.dev1.id}" ]
+}
+`
+
+const spaceRoleBundleResourceUpdate = spaceRoleBundleResourceCommon + `
+
+resource "cloudfoundry_space_role_bundle" "developers" {
+	space = "${data.cloudfoundry_space.space.id}"
+	roles = [ "developers", "auditors" ]
+	users = [
+		"${cloudfoundry_user.dev1.id}",
+		"${cloudfoundry_user.dev2.id}"
+	]
+}
+`
+
+func TestAccSpaceRoleBundle_normal(t *testing.T) {
+
+	ref := "cloudfoundry_space_role_bundle.developers"
+
+	resource.Test(t,
+		resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+
+				resource.TestStep{
+					Config: spaceRoleBundleResource,
+					Check: resource.ComposeTestCheckFunc(
+						checkSpaceRoleBundleExists(ref),
+						resource.TestCheckResourceAttr(
+							ref, "roles.#", "2"),
+						resource.TestCheckResourceAttr(
+							ref, "users.#", "1"),
+					),
+				},
+				resource.TestStep{
+					Config: spaceRoleBundleResourceUpdate,
+					Check: resource.ComposeTestCheckFunc(
+						checkSpaceRoleBundleExists(ref),
+						resource.TestCheckResourceAttr(
+							ref, "users.#", "2"),
+					),
+				},
+			},
+		})
+}
+
+func checkSpaceRoleBundleExists(resource string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) (err error) {
+
+		session := testAccProvider.Meta().(*cfapi.Session)
+
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("space role bundle '%s' not found in terraform state", resource)
+		}
+
+		session.Log.DebugMessage(
+			"terraform state for resource '%s': %# v",
+			resource, rs)
+
+		attributes := rs.Primary.Attributes
+		space := attributes["space"]
+
+		sm := session.SpaceManager()
+
+		// every user of the bundle must hold every role of the bundle
+		for _, role := range []cfapi.SpaceRole{cfapi.SpaceRoleDeveloper, cfapi.SpaceRoleAuditor} {
+			userIDs, err := sm.ListUsers(space, role)
+			if err != nil {
+				return err
+			}
+			holders := make(map[string]bool)
+			for _, u := range userIDs {
+				holders[u.(string)] = true
+			}
+			for k, v := range attributes {
+				if k == "users.#" || len(k) < 6 || k[:6] != "users." {
+					continue
+				}
+				if !holders[v] {
+					return fmt.Errorf("user '%s' does not hold role '%s' in space '%s'", v, role, space)
+				}
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,121 @@
+package cloudfoundry
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func resourceUaaIdentityProvider() *schema.Resource {
+
+	return &schema.Resource{
+
+		Create: resourceUaaIdentityProviderCreate,
+		Read:   resourceUaaIdentityProviderRead,
+		Update: resourceUaaIdentityProviderUpdate,
+		Delete: resourceUaaIdentityProviderDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"origin_key": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"active": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"config": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validateJSONString,
+			},
+		},
+	}
+}
+
+// uaaIdentityProviderFromSchema -
+func uaaIdentityProviderFromSchema(d *schema.ResourceData) cfapi.UAAIdentityProvider {
+	return cfapi.UAAIdentityProvider{
+		Name:      d.Get("name").(string),
+		OriginKey: d.Get("origin_key").(string),
+		Type:      d.Get("type").(string),
+		Active:    d.Get("active").(bool),
+		Config:    json.RawMessage(d.Get("config").(string)),
+	}
+}
+
+func resourceUaaIdentityProviderCreate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	provider, err := session.UserManager().CreateIdentityProvider(uaaIdentityProviderFromSchema(d))
+	if err != nil {
+		return err
+	}
+	d.SetId(provider.ID)
+	return nil
+}
+
+func resourceUaaIdentityProviderRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	provider, err := session.UserManager().GetIdentityProvider(d.Id())
+	if err != nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", provider.Name)
+	d.Set("origin_key", provider.OriginKey)
+	d.Set("type", provider.Type)
+	d.Set("active", provider.Active)
+	// 'config' is not read back as UAA redacts secrets (e.g. bindPassword,
+	// relyingPartySecret) in responses, which would cause perpetual diffs
+	return nil
+}
+
+func resourceUaaIdentityProviderUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	return session.UserManager().UpdateIdentityProvider(d.Id(), uaaIdentityProviderFromSchema(d))
+}
+
+func resourceUaaIdentityProviderDelete(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	return session.UserManager().DeleteIdentityProvider(d.Id())
+}
@@ -0,0 +1,66 @@
+package cloudfoundry
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestUaaIdentityProvider_fake(t *testing.T) {
+
+	f, session := newFakeSession(t)
+	defer f.Close()
+
+	provider := map[string]interface{}{
+		"id":        "idp-one",
+		"name":      "Corporate LDAP",
+		"originKey": "ldap",
+		"type":      "ldap",
+		"active":    true,
+		"config":    map[string]interface{}{"baseUrl": "ldap://ldap.example.com"},
+	}
+	f.Stub("POST", "/identity-providers", 201, provider)
+	f.Stub("GET", "/identity-providers/idp-one", 200, provider)
+
+	d := schema.TestResourceDataRaw(t, resourceUaaIdentityProvider().Schema, map[string]interface{}{
+		"name":       "Corporate LDAP",
+		"origin_key": "ldap",
+		"type":       "ldap",
+		"config":     `{"baseUrl":"ldap://ldap.example.com"}`,
+	})
+	if err := resourceUaaIdentityProviderCreate(d, session); err != nil {
+		t.Fatalf("create returned error: %s", err)
+	}
+	if d.Id() != "idp-one" {
+		t.Errorf("expected the provider ID, got '%s'", d.Id())
+	}
+
+	if err := resourceUaaIdentityProviderRead(d, session); err != nil {
+		t.Fatalf("read returned error: %s", err)
+	}
+	if v := d.Get("origin_key").(string); v != "ldap" {
+		t.Errorf("expected the origin key to be read back, got '%s'", v)
+	}
+	if !d.Get("active").(bool) {
+		t.Error("expected 'active' to be read back as true")
+	}
+
+	f.Stub("PUT", "/identity-providers/idp-one", 200, provider)
+	if err := resourceUaaIdentityProviderUpdate(d, session); err != nil {
+		t.Errorf("update returned error: %s", err)
+	}
+
+	f.Stub("DELETE", "/identity-providers/idp-one", 200, provider)
+	if err := resourceUaaIdentityProviderDelete(d, session); err != nil {
+		t.Errorf("delete returned error: %s", err)
+	}
+
+	// a provider removed out of band clears the state
+	d.SetId("idp-gone")
+	if err := resourceUaaIdentityProviderRead(d, session); err != nil {
+		t.Fatalf("read returned error: %s", err)
+	}
+	if len(d.Id()) != 0 {
+		t.Error("expected the ID to be cleared for a removed provider")
+	}
+}
@@ -31,6 +31,11 @@ func resourceUser() *schema.Resource {
 				Optional:  true,
 				Sensitive: true,
 			},
+			"password_version": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
 			"origin": &schema.Schema{
 				Type:     schema.TypeString,
 				ForceNew: true,
@@ -166,6 +171,9 @@ func resourceUserUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 
 		updatePassword, oldPassword, newPassword := getResourceChange("password", d)
+		// bumping the version counter forces a rotation to the current
+		// password value, e.g. when it is derived from the counter
+		updatePassword = updatePassword || d.HasChange("password_version")
 		if updatePassword {
 			err := um.ChangePassword(id, oldPassword, newPassword)
 			if err != nil {
@@ -0,0 +1,97 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+func resourceUserInvitation() *schema.Resource {
+
+	return &schema.Resource{
+
+		Create: resourceUserInvitationCreate,
+		Read:   resourceUserInvitationRead,
+		Delete: resourceUserInvitationDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			"email": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"redirect_uri": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"invite_link": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"accepted": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceUserInvitationCreate(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	email := d.Get("email").(string)
+	userID, inviteLink, err := session.UserManager().InviteUser(email, d.Get("redirect_uri").(string))
+	if err != nil {
+		return err
+	}
+	session.Log.DebugMessage("Invited user '%s' as '%s'.", email, userID)
+
+	d.SetId(userID)
+	d.Set("invite_link", inviteLink)
+	d.Set("accepted", false)
+	return nil
+}
+
+func resourceUserInvitationRead(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+
+	user, err := session.UserManager().GetUser(d.Id())
+	if err != nil {
+		if strings.Contains(err.Error(), "status code: 404") {
+			d.SetId("")
+			err = nil
+		}
+		return err
+	}
+
+	if len(user.Emails) > 0 {
+		d.Set("email", user.Emails[0].Value)
+	}
+	d.Set("accepted", user.Verified != nil && *user.Verified)
+	return nil
+}
+
+func resourceUserInvitationDelete(d *schema.ResourceData, meta interface{}) (err error) {
+
+	session := meta.(*cfapi.Session)
+	if session == nil {
+		return fmt.Errorf("client is nil")
+	}
+	return session.UserManager().Delete(d.Id())
+}
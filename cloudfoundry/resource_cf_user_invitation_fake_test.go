@@ -0,0 +1,75 @@
+package cloudfoundry
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestUserInvitation_fake(t *testing.T) {
+
+	f, session := newFakeSession(t)
+	defer f.Close()
+
+	f.Stub("POST", "/invite_users", 201, map[string]interface{}{
+		"new_invites": []interface{}{
+			map[string]interface{}{
+				"email":      "jdoe@example.com",
+				"userId":     "user-one",
+				"inviteLink": "https://login.example.com/invitations/accept?code=abc",
+				"success":    true,
+			},
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceUserInvitation().Schema, map[string]interface{}{
+		"email":        "jdoe@example.com",
+		"redirect_uri": "https://console.example.com",
+	})
+	if err := resourceUserInvitationCreate(d, session); err != nil {
+		t.Fatalf("create returned error: %s", err)
+	}
+	if d.Id() != "user-one" {
+		t.Errorf("expected the invited user's ID, got '%s'", d.Id())
+	}
+	if v := d.Get("invite_link").(string); len(v) == 0 {
+		t.Error("expected 'invite_link' to be set")
+	}
+	if d.Get("accepted").(bool) {
+		t.Error("expected 'accepted' to be false for a fresh invitation")
+	}
+
+	// once the invitation is accepted the user is verified
+	f.Stub("GET", "/Users/user-one", 200, map[string]interface{}{
+		"id":       "user-one",
+		"userName": "jdoe@example.com",
+		"emails":   []interface{}{map[string]interface{}{"value": "jdoe@example.com"}},
+		"verified": true,
+	})
+	if err := resourceUserInvitationRead(d, session); err != nil {
+		t.Fatalf("read returned error: %s", err)
+	}
+	if !d.Get("accepted").(bool) {
+		t.Error("expected 'accepted' to be true for a verified user")
+	}
+
+	f.Stub("DELETE", "/v2/users/user-one", 200, map[string]interface{}{})
+	f.Stub("DELETE", "/Users/user-one", 200, map[string]interface{}{})
+	if err := resourceUserInvitationDelete(d, session); err != nil {
+		t.Errorf("delete returned error: %s", err)
+	}
+
+	// an invitation UAA refuses fails the create with the reported reason
+	f.Stub("POST", "/invite_users", 201, map[string]interface{}{
+		"new_invites": []interface{}{
+			map[string]interface{}{
+				"email":        "jdoe@example.com",
+				"success":      false,
+				"errorMessage": "user is already verified",
+			},
+		},
+	})
+	if err := resourceUserInvitationCreate(d, session); err == nil {
+		t.Error("expected an error for a refused invitation")
+	}
+}
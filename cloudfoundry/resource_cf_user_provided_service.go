@@ -1,12 +1,16 @@
 package cloudfoundry
 
 import (
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"strings"
 
+	uuid "github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/structure"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
 )
 
@@ -66,6 +70,34 @@ func resourceUserProvidedService() *schema.Resource {
 				ConflictsWith:    []string{"credentials"},
 				DiffSuppressFunc: structure.SuppressJsonDiff,
 			},
+			"generate": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "password",
+							ValidateFunc: validation.StringInSlice([]string{"password", "uuid"}, false),
+						},
+						"length": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  32,
+						},
+					},
+				},
+			},
+			"generated_credentials": &schema.Schema{
+				Type:      schema.TypeMap,
+				Computed:  true,
+				Sensitive: true,
+			},
 		},
 	}
 }
@@ -106,6 +138,10 @@ func resourceUserProvidedServiceCreate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
+	if err = applyGeneratedCredentials(d, credentials); err != nil {
+		return err
+	}
+
 	sm := session.ServiceManager()
 
 	if id, err = sm.CreateUserProvidedService(name, space, credentials, syslogDrainURL, routeServiceURL); err != nil {
@@ -154,11 +190,28 @@ func resourceUserProvidedServiceRead(d *schema.ResourceData, meta interface{}) (
 		d.Set("route_service_url", ups.RouteServiceURL)
 	}
 
+	// keep generated credentials out of the declared credential arguments so
+	// they do not show up as drift against the configuration
+	credentials := make(map[string]interface{})
+	generated := make(map[string]interface{})
+	generatedKeys := make(map[string]bool)
+	for _, g := range getListOfStructs(d.Get("generate")) {
+		generatedKeys[g["key"].(string)] = true
+	}
+	for k, v := range ups.Credentials {
+		if generatedKeys[k] {
+			generated[k] = v
+		} else {
+			credentials[k] = v
+		}
+	}
+	d.Set("generated_credentials", generated)
+
 	if _, hasJSON := d.GetOk("credentials_json"); hasJSON {
-		bytes, _ := json.Marshal(ups.Credentials)
+		bytes, _ := json.Marshal(credentials)
 		d.Set("credentials_json", string(bytes))
 	} else {
-		d.Set("credentials", ups.Credentials)
+		d.Set("credentials", credentials)
 	}
 
 	session.Log.DebugMessage("Read User Provided Service : %# v", ups)
@@ -204,6 +257,10 @@ func resourceUserProvidedServiceUpdate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
+	if err = applyGeneratedCredentials(d, credentials); err != nil {
+		return err
+	}
+
 	if _, err = sm.UpdateUserProvidedService(id, name, credentials, syslogDrainURL, routeServiceURL); err != nil {
 		return err
 	}
@@ -211,6 +268,61 @@ func resourceUserProvidedServiceUpdate(d *schema.ResourceData, meta interface{})
 	return nil
 }
 
+// applyGeneratedCredentials - adds the values for the declared 'generate'
+// entries to the given credentials, reusing values already recorded in state
+// so each credential is only generated once
+func applyGeneratedCredentials(d *schema.ResourceData, credentials map[string]interface{}) (err error) {
+
+	existing := d.Get("generated_credentials").(map[string]interface{})
+	generated := make(map[string]interface{})
+
+	for _, g := range getListOfStructs(d.Get("generate")) {
+
+		key := g["key"].(string)
+		if _, ok := credentials[key]; ok {
+			return fmt.Errorf("credential '%s' is both declared and listed in a 'generate' block", key)
+		}
+
+		var value string
+		if v, ok := existing[key]; ok {
+			value = v.(string)
+		} else {
+			switch g["type"].(string) {
+			case "uuid":
+				if value, err = uuid.GenerateUUID(); err != nil {
+					return err
+				}
+			default:
+				if value, err = generateRandomPassword(g["length"].(int)); err != nil {
+					return err
+				}
+			}
+		}
+		generated[key] = value
+		credentials[key] = value
+	}
+
+	d.Set("generated_credentials", generated)
+	return nil
+}
+
+// generateRandomPassword - returns a random alphanumeric string of the
+// given length built from a cryptographically secure source
+func generateRandomPassword(length int) (string, error) {
+
+	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+	password := make([]byte, length)
+	for i := range password {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+		if err != nil {
+			return "", err
+		}
+		password[i] = charset[n.Int64()]
+	}
+	return string(password), nil
+}
+
 func resourceUserProvidedServiceDelete(d *schema.ResourceData, meta interface{}) (err error) {
 
 	session := meta.(*cfapi.Session)
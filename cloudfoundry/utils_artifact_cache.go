@@ -0,0 +1,90 @@
+package cloudfoundry
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+// appArtifactCacheKey - derives the cache key for the application source
+// described by the given configuration; returns "" when the source is not
+// cacheable i.e. local files or git clones tracking a mutable branch
+func appArtifactCacheKey(d *schema.ResourceData) string {
+
+	if v, ok := d.GetOk("url"); ok {
+		url := v.(string)
+		if strings.HasPrefix(url, "file://") {
+			return ""
+		}
+		return artifactChecksum("url|" + url)
+	}
+	if v, ok := d.Get("git").([]interface{}); ok && len(v) > 0 {
+		gitArgs := v[0].(map[string]interface{})
+		tag := gitArgs["tag"].(string)
+		if len(tag) == 0 {
+			// branches move between applies so those clones are always refreshed
+			return ""
+		}
+		return artifactChecksum(fmt.Sprintf("git|%s|%s", gitArgs["url"].(string), tag))
+	}
+	if v, ok := d.Get("github_release").([]interface{}); ok && len(v) > 0 {
+		githubArgs := v[0].(map[string]interface{})
+		return artifactChecksum(fmt.Sprintf("github_release|%s|%s|%s|%s",
+			githubArgs["owner"], githubArgs["repo"], githubArgs["version"], githubArgs["filename"]))
+	}
+	return ""
+}
+
+// artifactChecksum - checksum of the source descriptor used as cache key
+func artifactChecksum(source string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(source)))
+}
+
+// cachedArtifact - returns a temporary copy of the cached artifact for the
+// given key, or "" when caching is disabled or the artifact is not cached;
+// a copy is handed out so the cleanup after upload leaves the cache intact
+func cachedArtifact(session *cfapi.Session, key string) (path string, err error) {
+
+	if len(session.AppArtifactCache) == 0 || len(key) == 0 {
+		return "", nil
+	}
+
+	cached := filepath.Join(session.AppArtifactCache, key)
+	if _, err = os.Stat(cached); err != nil {
+		return "", nil
+	}
+
+	tmp, err := ioutil.TempDir("", "cfapp-cache")
+	if err != nil {
+		return "", err
+	}
+	path = filepath.Join(tmp, "artifact")
+	if err = cfapi.CopyResource(cached, path); err != nil {
+		os.RemoveAll(tmp)
+		return "", err
+	}
+	return path, nil
+}
+
+// cacheArtifact - stores the artifact at the given path under the cache key;
+// failures only leave the artifact uncached and are logged
+func cacheArtifact(session *cfapi.Session, key, path string) {
+
+	if len(session.AppArtifactCache) == 0 || len(key) == 0 {
+		return
+	}
+	if err := os.MkdirAll(session.AppArtifactCache, 0755); err != nil {
+		session.Log.DebugMessage("Not caching artifact, could not create cache directory '%s': %s",
+			session.AppArtifactCache, err.Error())
+		return
+	}
+	if err := cfapi.CopyResource(path, filepath.Join(session.AppArtifactCache, key)); err != nil {
+		session.Log.DebugMessage("Failed to cache artifact '%s': %s", path, err.Error())
+	}
+}
@@ -0,0 +1,55 @@
+package cloudfoundry
+
+import (
+	"os"
+
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+// cleanupAction - a single undo step registered while a create is in flight
+type cleanupAction struct {
+	what string
+	fn   func() error
+}
+
+// cleanupRegistry - collects undo steps for partially created state (uploaded
+// packages, temp files, deposed apps, created-but-unbound routes) so an apply
+// error does not leak them; actions run in reverse registration order and
+// failures are logged rather than masking the original error
+type cleanupRegistry struct {
+	session *cfapi.Session
+	actions []cleanupAction
+}
+
+func newCleanupRegistry(session *cfapi.Session) *cleanupRegistry {
+	return &cleanupRegistry{session: session}
+}
+
+// register - adds an undo step described by 'what' for the cleanup log
+func (c *cleanupRegistry) register(what string, fn func() error) {
+	c.actions = append(c.actions, cleanupAction{what: what, fn: fn})
+}
+
+// registerPath - registers removal of a temporary file or directory
+func (c *cleanupRegistry) registerPath(what string, path string) {
+	c.register(what, func() error {
+		return os.RemoveAll(path)
+	})
+}
+
+// runOnError - deferred at the top of a create; runs the registered undo
+// steps only when the create is returning an error
+func (c *cleanupRegistry) runOnError(err *error) {
+	if *err == nil {
+		return
+	}
+	for i := len(c.actions) - 1; i >= 0; i-- {
+		a := c.actions[i]
+		if e := a.fn(); e != nil {
+			c.session.Log.DebugMessage("Cleanup of %s after failed apply also failed: %s", a.what, e.Error())
+		} else {
+			c.session.Log.DebugMessage("Cleaned up %s after failed apply.", a.what)
+		}
+	}
+	c.actions = nil
+}
@@ -0,0 +1,83 @@
+package cloudfoundry
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// parseEnvironmentFile - reads environment variables from the given file,
+// which may be a JSON object or a dotenv style file with one KEY=VALUE per
+// line; '#' comments, blank lines and an optional 'export ' prefix are
+// ignored and values may be single or double quoted
+func parseEnvironmentFile(path string) (map[string]interface{}, error) {
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read environment_file '%s': %s", path, err.Error())
+	}
+
+	env := make(map[string]interface{})
+	if strings.HasPrefix(strings.TrimSpace(string(content)), "{") {
+		if err = json.Unmarshal(content, &env); err != nil {
+			return nil, fmt.Errorf("could not parse environment_file '%s' as JSON: %s", path, err.Error())
+		}
+		return env, nil
+	}
+
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 || len(strings.TrimSpace(parts[0])) == 0 {
+			return nil, fmt.Errorf("could not parse line %d of environment_file '%s': expected KEY=VALUE", i+1, path)
+		}
+		value := strings.TrimSpace(parts[1])
+		if len(value) >= 2 &&
+			((value[0] == '"' && value[len(value)-1] == '"') ||
+				(value[0] == '\'' && value[len(value)-1] == '\'')) {
+			value = value[1 : len(value)-1]
+		}
+		env[strings.TrimSpace(parts[0])] = value
+	}
+	return env, nil
+}
+
+// environmentFileHash - a stable hash of the parsed file contents, stored in
+// state so changes to the file are detected as a diff
+func environmentFileHash(env map[string]interface{}) string {
+	content, _ := json.Marshal(env)
+	return fmt.Sprintf("%x", sha256.Sum256(content))
+}
+
+// mergedAppEnvironment - combines the app's 'environment_file' with the
+// inline 'environment' map; inline values take precedence over the file
+func mergedAppEnvironment(d *schema.ResourceData) (*map[string]interface{}, error) {
+
+	merged := make(map[string]interface{})
+	if v, ok := d.GetOk("environment_file"); ok {
+		fileEnv, err := parseEnvironmentFile(v.(string))
+		if err != nil {
+			return nil, err
+		}
+		for k, vv := range fileEnv {
+			merged[k] = vv
+		}
+	}
+	if v, ok := d.GetOk("environment"); ok {
+		for k, vv := range v.(map[string]interface{}) {
+			merged[k] = vv
+		}
+	}
+	if len(merged) == 0 {
+		return nil, nil
+	}
+	return &merged, nil
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+// +build !windows
+
+package cloudfoundry
+
+import "golang.org/x/sys/unix"
+
+// freeDiskSpace - bytes available to unprivileged users on the filesystem
+// holding the given path
+func freeDiskSpace(path string) (int64, error) {
+
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return -1, err
+	}
+	return int64(stat.Bavail) * int64(stat.Bsize), nil
+}
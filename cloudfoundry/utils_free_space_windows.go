@@ -0,0 +1,10 @@
+//go:build windows
+// +build windows
+
+package cloudfoundry
+
+// freeDiskSpace - free space reporting is not implemented on windows, a
+// negative value skips the pre-check
+func freeDiskSpace(path string) (int64, error) {
+	return -1, nil
+}
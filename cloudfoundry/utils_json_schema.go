@@ -0,0 +1,106 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"strings"
+)
+
+// validateAgainstJSONSchema - validates a value against a broker published
+// JSON schema, supporting the subset of the specification most brokers use:
+// 'type', 'required', 'properties', 'additionalProperties' and 'enum',
+// recursing into nested objects; returns one message per violated field
+func validateAgainstJSONSchema(value interface{}, jsonSchema map[string]interface{}, path string) (errs []string) {
+
+	at := func(field string) string {
+		if len(path) == 0 {
+			return field
+		}
+		return path + "." + field
+	}
+	name := path
+	if len(name) == 0 {
+		name = "json_params"
+	}
+
+	if t, ok := jsonSchema["type"].(string); ok {
+		if !jsonValueHasType(value, t) {
+			return []string{fmt.Sprintf("'%s' must be of type %s", name, t)}
+		}
+	}
+
+	if enum, ok := jsonSchema["enum"].([]interface{}); ok {
+		matched := false
+		for _, allowed := range enum {
+			if value == allowed {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			values := make([]string, len(enum))
+			for i, allowed := range enum {
+				values[i] = fmt.Sprintf("%v", allowed)
+			}
+			errs = append(errs, fmt.Sprintf("'%s' must be one of: %s", name, strings.Join(values, ", ")))
+		}
+	}
+
+	object, isObject := value.(map[string]interface{})
+	if !isObject {
+		return errs
+	}
+
+	if required, ok := jsonSchema["required"].([]interface{}); ok {
+		for _, r := range required {
+			field, ok := r.(string)
+			if !ok {
+				continue
+			}
+			if _, found := object[field]; !found {
+				errs = append(errs, fmt.Sprintf("required parameter '%s' is missing", at(field)))
+			}
+		}
+	}
+
+	properties, _ := jsonSchema["properties"].(map[string]interface{})
+	for field, v := range object {
+		propertySchema, known := properties[field].(map[string]interface{})
+		if !known {
+			if additional, ok := jsonSchema["additionalProperties"].(bool); ok && !additional {
+				errs = append(errs, fmt.Sprintf("parameter '%s' is not accepted by the broker", at(field)))
+			}
+			continue
+		}
+		errs = append(errs, validateAgainstJSONSchema(v, propertySchema, at(field))...)
+	}
+	return errs
+}
+
+// jsonValueHasType - checks an unmarshalled JSON value against a JSON schema
+// type name
+func jsonValueHasType(value interface{}, t string) bool {
+
+	switch t {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		v, ok := value.(float64)
+		return ok && v == float64(int64(v))
+	case "null":
+		return value == nil
+	}
+	return true
+}
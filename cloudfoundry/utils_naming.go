@@ -0,0 +1,29 @@
+package cloudfoundry
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+// appliedName - decorates the given name with the provider wide name_prefix
+// and name_suffix when the resource opted in via apply_name_affixes
+func appliedName(d *schema.ResourceData, session *cfapi.Session, name string) string {
+
+	if !d.Get("apply_name_affixes").(bool) {
+		return name
+	}
+	return session.NamePrefix + name + session.NameSuffix
+}
+
+// strippedName - reverses appliedName when reading names back from the
+// platform so the configured name is compared against state
+func strippedName(d *schema.ResourceData, session *cfapi.Session, name string) string {
+
+	if !d.Get("apply_name_affixes").(bool) {
+		return name
+	}
+	name = strings.TrimPrefix(name, session.NamePrefix)
+	return strings.TrimSuffix(name, session.NameSuffix)
+}
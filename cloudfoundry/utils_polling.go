@@ -0,0 +1,85 @@
+package cloudfoundry
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+// waitForStateWithBackoff - drives a StateChangeConf poll loop with
+// exponential backoff and jitter instead of the fixed PollInterval, reducing
+// cloud controller load on large applies with many concurrent waits. The
+// backoff starts at the configured Delay and doubles up to the configured
+// PollInterval, which acts as the interval cap.
+func waitForStateWithBackoff(conf *resource.StateChangeConf) (interface{}, error) {
+
+	maxInterval := conf.PollInterval
+	if maxInterval <= 0 {
+		maxInterval = 30 * time.Second
+	}
+	interval := conf.Delay
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+
+	deadline := time.Now().Add(conf.Timeout)
+	notFound := 0
+
+	for {
+		time.Sleep(jitteredInterval(interval))
+
+		result, state, err := conf.Refresh()
+		if err != nil {
+			return nil, err
+		}
+
+		if result == nil {
+			notFound++
+			if conf.NotFoundChecks > 0 && notFound >= conf.NotFoundChecks {
+				return nil, &resource.NotFoundError{Retries: notFound}
+			}
+		} else {
+			notFound = 0
+			for _, t := range conf.Target {
+				if state == t {
+					return result, nil
+				}
+			}
+			pending := false
+			for _, p := range conf.Pending {
+				if state == p {
+					pending = true
+					break
+				}
+			}
+			if !pending {
+				return nil, &resource.UnexpectedStateError{
+					State:         state,
+					ExpectedState: conf.Target,
+				}
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return nil, &resource.TimeoutError{
+				LastState:     state,
+				ExpectedState: conf.Target,
+				Timeout:       conf.Timeout,
+			}
+		}
+
+		if interval < maxInterval {
+			interval *= 2
+			if interval > maxInterval {
+				interval = maxInterval
+			}
+		}
+	}
+}
+
+// jitteredInterval - randomizes an interval by up to ±25% so concurrent
+// waits do not poll the cloud controller in lockstep
+func jitteredInterval(interval time.Duration) time.Duration {
+	return interval*3/4 + time.Duration(rand.Int63n(int64(interval)/2+1))
+}
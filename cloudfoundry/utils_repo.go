@@ -23,11 +23,12 @@ func getRepositoryFromConfig(d *schema.ResourceData) (repository repo.Repository
 		gitArgs := v[0].(map[string]interface{})
 
 		var (
-			arg, repoURL               string
-			user, password, privateKey *string
+			arg, repoURL, gitProvider         string
+			user, password, privateKey, token *string
 		)
 
 		repoURL = gitArgs["url"].(string)
+		gitProvider = gitArgs["provider"].(string)
 
 		if arg = gitArgs["branch"].(string); len(arg) > 0 {
 			version = arg
@@ -49,8 +50,12 @@ func getRepositoryFromConfig(d *schema.ResourceData) (repository repo.Repository
 			s := arg
 			privateKey = &s
 		}
+		if arg = gitArgs["token"].(string); len(arg) > 0 {
+			s := arg
+			token = &s
+		}
 
-		if repository, err = repoManager.GetGitRepository(name, repoURL, user, password, privateKey); err != nil {
+		if repository, err = repoManager.GetGitRepository(name, repoURL, user, password, privateKey, token, gitProvider); err != nil {
 			return repository, err
 		}
 
@@ -0,0 +1,17 @@
+package cloudfoundry
+
+import (
+	"os/exec"
+	"runtime"
+)
+
+// shellCommand - builds the platform shell invocation for a configured
+// command line, using 'cmd /C' on Windows and '/bin/sh -c' elsewhere, the
+// same way terraform's local-exec provisioner does
+func shellCommand(command string) *exec.Cmd {
+
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("/bin/sh", "-c", command)
+}
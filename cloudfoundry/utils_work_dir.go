@@ -0,0 +1,92 @@
+package cloudfoundry
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-cloudfoundry/cloudfoundry/cfapi"
+)
+
+// appWorkDir - resolves the directory used for artifact downloads and other
+// scratch files, preferring the app level 'work_dir' over the provider level
+// one; "" falls back to the OS temp dir
+func appWorkDir(d *schema.ResourceData, session *cfapi.Session) (string, error) {
+
+	dir := session.WorkDir
+	if v, ok := d.GetOk("work_dir"); ok {
+		dir = v.(string)
+	}
+	if len(dir) == 0 {
+		return "", nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("could not create work_dir '%s': %s", dir, err.Error())
+	}
+	return dir, nil
+}
+
+// checkFreeDiskSpace - fails with a descriptive error when the filesystem
+// holding the given directory has less than the required number of bytes
+// available; the check is skipped when the size is unknown or the platform
+// cannot report free space
+func checkFreeDiskSpace(dir string, required int64) error {
+
+	if required <= 0 {
+		return nil
+	}
+	if len(dir) == 0 {
+		dir = os.TempDir()
+	}
+	free, err := freeDiskSpace(dir)
+	if err != nil || free < 0 {
+		return nil
+	}
+	if free < required {
+		return fmt.Errorf(
+			"not enough free disk space in '%s' to download the application artifact: %d MB required but only %d MB available; set 'work_dir' to a directory on a larger volume",
+			dir, required/(1024*1024)+1, free/(1024*1024))
+	}
+	return nil
+}
+
+// artifactSize - total size in bytes of the application artifact at the
+// given path, which may be a single file or a directory tree
+func artifactSize(path string) (int64, error) {
+	var size int64
+	err := filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+// checkMaxUploadSize - fails when the application artifact at the given path
+// exceeds the provider level 'max_upload_size_mb', so the apply aborts with a
+// clear message instead of the Cloud Controller rejecting the upload mid-way;
+// a limit of 0 disables the check
+func checkMaxUploadSize(session *cfapi.Session, path string) error {
+
+	if session.MaxUploadSizeMB <= 0 {
+		return nil
+	}
+
+	size, err := artifactSize(path)
+	if err != nil {
+		return err
+	}
+
+	limit := int64(session.MaxUploadSizeMB) * 1024 * 1024
+	if size > limit {
+		return fmt.Errorf(
+			"application artifact at '%s' is %d MB which exceeds the configured 'max_upload_size_mb' of %d MB",
+			path, size/(1024*1024)+1, session.MaxUploadSizeMB)
+	}
+	return nil
+}